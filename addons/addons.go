@@ -0,0 +1,93 @@
+// Package addons manage add-ons of the current user or of an organisation.
+// Owner IDs are resolved by client.OwnerPath().
+package addons
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"go.clever-cloud.dev/client"
+	"go.clever-cloud.dev/client/applications"
+)
+
+func addonsPath(ownerID string) string {
+	return client.OwnerPath(ownerID) + "/addons"
+}
+
+func addonPath(ownerID, addonID string) string {
+	return fmt.Sprintf("%s/%s", addonsPath(ownerID), url.PathEscape(addonID))
+}
+
+func linkedAddonsPath(ownerID, applicationID string) string {
+	return fmt.Sprintf("%s/applications/%s/addons", client.OwnerPath(ownerID), url.PathEscape(applicationID))
+}
+
+// ListProviders returns add-on kinds with their plans.
+func ListProviders(ctx context.Context, c *client.Client) client.Response[[]Provider] {
+	return client.Get[[]Provider](ctx, c, "/v2/products/addonproviders")
+}
+
+// GetProvider returns an add-on kind with its plans.
+func GetProvider(ctx context.Context, c *client.Client, providerID string) client.Response[Provider] {
+	path := fmt.Sprintf("/v2/products/addonproviders/%s", url.PathEscape(providerID))
+
+	return client.Get[Provider](ctx, c, path)
+}
+
+// List add-ons of an owner.
+func List(ctx context.Context, c *client.Client, ownerID string) client.Response[[]Addon] {
+	return client.Get[[]Addon](ctx, c, addonsPath(ownerID))
+}
+
+// Get an add-on.
+func Get(ctx context.Context, c *client.Client, ownerID, addonID string) client.Response[Addon] {
+	return client.Get[Addon](ctx, c, addonPath(ownerID, addonID))
+}
+
+// Create provision an add-on.
+func Create(ctx context.Context, c *client.Client, ownerID string, addon AddonRequest) client.Response[Addon] {
+	return client.Post[Addon](ctx, c, addonsPath(ownerID), addon)
+}
+
+// Rename an add-on.
+func Rename(ctx context.Context, c *client.Client, ownerID, addonID, name string) client.Response[Addon] {
+	return client.Put[Addon](ctx, c, addonPath(ownerID, addonID), map[string]string{"name": name})
+}
+
+// ChangePlan migrate an add-on to another plan of its provider.
+func ChangePlan(ctx context.Context, c *client.Client, ownerID, addonID, planID string) client.Response[Addon] {
+	return client.Put[Addon](ctx, c, addonPath(ownerID, addonID)+"/plan", map[string]string{"planId": planID})
+}
+
+// Delete an add-on and its data.
+func Delete(ctx context.Context, c *client.Client, ownerID, addonID string) client.Response[client.Nothing] {
+	return client.Delete[client.Nothing](ctx, c, addonPath(ownerID, addonID))
+}
+
+// ListLinked returns add-ons linked to an application.
+func ListLinked(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[[]Addon] {
+	return client.Get[[]Addon](ctx, c, linkedAddonsPath(ownerID, applicationID))
+}
+
+// Link an add-on to an application, its variables are exposed to the application.
+func Link(ctx context.Context, c *client.Client, ownerID, applicationID, addonID string) client.Response[client.Nothing] {
+	return client.Post[client.Nothing](ctx, c, linkedAddonsPath(ownerID, applicationID), addonID)
+}
+
+// Unlink an add-on from an application.
+func Unlink(ctx context.Context, c *client.Client, ownerID, applicationID, addonID string) client.Response[client.Nothing] {
+	path := fmt.Sprintf("%s/%s", linkedAddonsPath(ownerID, applicationID), url.PathEscape(addonID))
+
+	return client.Delete[client.Nothing](ctx, c, path)
+}
+
+// GetEnv returns variables exposed by an add-on.
+func GetEnv(ctx context.Context, c *client.Client, ownerID, addonID string) client.Response[[]applications.EnvVariable] {
+	return client.Get[[]applications.EnvVariable](ctx, c, addonPath(ownerID, addonID)+"/env")
+}
+
+// GetSSO returns parameters to log in to the add-on dashboard.
+func GetSSO(ctx context.Context, c *client.Client, ownerID, addonID string) client.Response[SSO] {
+	return client.Get[SSO](ctx, c, addonPath(ownerID, addonID)+"/sso")
+}
@@ -0,0 +1,151 @@
+package addons_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.clever-cloud.dev/client/addons"
+	"go.clever-cloud.dev/client/internal/clienttest"
+)
+
+func Test_GetProvider(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method: http.MethodGet,
+		Path:   "/v2/products/addonproviders/postgresql-addon",
+		Response: `{"id":"postgresql-addon","regions":["par"],"plans":[
+			{"id":"plan_s","slug":"s","zones":["par"],"features":[{"name":"Max DB size","type":"BYTES","value":"10 GB"}]}
+		]}`,
+	})
+
+	provider := clienttest.Payload(t, addons.GetProvider(context.Background(), c, "postgresql-addon"))
+
+	plan := provider.Plan("s")
+	if plan == nil || len(plan.Features) != 1 || plan.Features[0].Value != "10 GB" {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+
+	if provider.Plan("xl") != nil {
+		t.Error("expect no plan for an unknown slug")
+	}
+}
+
+func Test_Create(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodPost,
+		Path:     "/v2/organisations/orga_1/addons",
+		Response: `{"id":"addon_1","realId":"postgresql_1","plan":{"id":"plan_s"}}`,
+		Check: func(t *testing.T, r *http.Request) {
+			var req addons.AddonRequest
+			if clienttest.DecodeBody(t, r, &req); req.Plan != "plan_s" || req.ProviderID != "postgresql-addon" {
+				t.Errorf("unexpected creation request: %+v", req)
+			}
+		},
+	})
+
+	addon := clienttest.Payload(t, addons.Create(context.Background(), c, "orga_1", addons.AddonRequest{
+		Name:       "db",
+		ProviderID: "postgresql-addon",
+		Plan:       "plan_s",
+		Region:     "par",
+	}))
+	if addon.RealID != "postgresql_1" {
+		t.Errorf("unexpected add-on: %+v", addon)
+	}
+}
+
+func Test_Rename(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodPut,
+		Path:     "/v2/organisations/orga_1/addons/addon_1",
+		Response: `{"id":"addon_1","name":"main-db"}`,
+		Check: func(t *testing.T, r *http.Request) {
+			var body map[string]string
+			if clienttest.DecodeBody(t, r, &body); len(body) != 1 || body["name"] != "main-db" {
+				t.Errorf("unexpected rename request: %+v", body)
+			}
+		},
+	})
+
+	if addon := clienttest.Payload(t, addons.Rename(context.Background(), c, "orga_1", "addon_1", "main-db")); addon.Name != "main-db" {
+		t.Errorf("unexpected add-on: %+v", addon)
+	}
+}
+
+func Test_ChangePlan(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodPut,
+		Path:     "/v2/organisations/orga_1/addons/addon_1/plan",
+		Response: `{"id":"addon_1","plan":{"id":"plan_m","slug":"m"}}`,
+		Check: func(t *testing.T, r *http.Request) {
+			var body map[string]string
+			if clienttest.DecodeBody(t, r, &body); len(body) != 1 || body["planId"] != "plan_m" {
+				t.Errorf("unexpected plan request: %+v", body)
+			}
+		},
+	})
+
+	if addon := clienttest.Payload(t, addons.ChangePlan(context.Background(), c, "orga_1", "addon_1", "plan_m")); addon.Plan.ID != "plan_m" {
+		t.Errorf("unexpected add-on: %+v", addon)
+	}
+}
+
+func Test_Link(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t,
+		clienttest.Endpoint{
+			Method: http.MethodPost,
+			Path:   "/v2/organisations/orga_1/applications/app_1/addons",
+			Check: func(t *testing.T, r *http.Request) {
+				var addonID string
+				if clienttest.DecodeBody(t, r, &addonID); addonID != "addon_1" {
+					t.Errorf("unexpected link request: %q", addonID)
+				}
+			},
+		},
+		clienttest.Endpoint{Method: http.MethodDelete, Path: "/v2/organisations/orga_1/applications/app_1/addons/addon_1"},
+	)
+	ctx := context.Background()
+
+	clienttest.Payload(t, addons.Link(ctx, c, "orga_1", "app_1", "addon_1"))
+	clienttest.Payload(t, addons.Unlink(ctx, c, "orga_1", "app_1", "addon_1"))
+}
+
+func Test_GetEnv(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/organisations/orga_1/addons/addon_1/env",
+		Response: `[{"name":"POSTGRESQL_ADDON_HOST","value":"db.example.com"}]`,
+	})
+
+	env := clienttest.Items(t, addons.GetEnv(context.Background(), c, "orga_1", "addon_1"), 1)
+	if env[0].Name != "POSTGRESQL_ADDON_HOST" {
+		t.Errorf("unexpected env: %+v", env)
+	}
+}
+
+func Test_GetSSO(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/organisations/orga_1/addons/addon_1/sso",
+		Response: `{"url":"https://dashboard.example.com/sso","id":"addon_1","token":"sso-token","timestamp":1700000000,"nav-data":"nav","user_id":"user_1"}`,
+	})
+
+	sso := clienttest.Payload(t, addons.GetSSO(context.Background(), c, "orga_1", "addon_1"))
+	if sso.Token != "sso-token" || sso.NavData != "nav" || sso.UserID != "user_1" || sso.Timestamp != 1700000000 {
+		t.Errorf("unexpected SSO parameters: %+v", sso)
+	}
+}
@@ -0,0 +1,88 @@
+package addons
+
+// Provider is a kind of add-on, like PostgreSQL or Cellar.
+type Provider struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Website      string    `json:"website"`
+	SupportEmail string    `json:"supportEmail"`
+	ShortDesc    string    `json:"shortDesc"`
+	LongDesc     string    `json:"longDesc"`
+	LogoURL      string    `json:"logoUrl"`
+	Status       string    `json:"status"`
+	OpenInNewTab bool      `json:"openInNewTab"`
+	CanUpgrade   bool      `json:"canUpgrade"`
+	Regions      []string  `json:"regions"`
+	Plans        []Plan    `json:"plans"`
+	Features     []Feature `json:"features"`
+}
+
+// Plan returns the provider plan with the given slug, nil if there is none.
+func (p *Provider) Plan(slug string) *Plan {
+	for i := range p.Plans {
+		if p.Plans[i].Slug == slug {
+			return &p.Plans[i]
+		}
+	}
+
+	return nil
+}
+
+// Feature describe a characteristic plans of a provider differ on.
+type Feature struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Plan is a size of add-on, with its price.
+type Plan struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Slug     string        `json:"slug"`
+	Price    float64       `json:"price"`
+	PriceID  string        `json:"price_id"`
+	Features []PlanFeature `json:"features"`
+	Zones    []string      `json:"zones"`
+}
+
+// PlanFeature is the value of a feature for a plan.
+type PlanFeature struct {
+	Name            string  `json:"name"`
+	Type            string  `json:"type"`
+	Value           string  `json:"value"`
+	ComputableValue *string `json:"computable_value"`
+	NameCode        *string `json:"name_code"`
+}
+
+// Addon is a provisioned add-on.
+type Addon struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	RealID       string   `json:"realId"`
+	Region       string   `json:"region"`
+	Provider     Provider `json:"provider"`
+	Plan         Plan     `json:"plan"`
+	CreationDate int64    `json:"creationDate"`
+	ConfigKeys   []string `json:"configKeys"`
+}
+
+// AddonRequest describe an add-on to provision.
+type AddonRequest struct {
+	Name       string `json:"name"`
+	ProviderID string `json:"providerId"`
+	// Plan ID, see Provider.Plan()
+	Plan    string            `json:"plan"`
+	Region  string            `json:"region"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// SSO holds parameters to log in to the add-on dashboard.
+type SSO struct {
+	URL       string `json:"url"`
+	ID        string `json:"id"`
+	Token     string `json:"token"`
+	Timestamp int64  `json:"timestamp"`
+	NavData   string `json:"nav-data"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+}
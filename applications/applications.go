@@ -0,0 +1,135 @@
+// Package applications manage applications of the current user or of an organisation.
+// Owner IDs are resolved by client.OwnerPath().
+package applications
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"go.clever-cloud.dev/client"
+)
+
+func applicationsPath(ownerID string) string {
+	return client.OwnerPath(ownerID) + "/applications"
+}
+
+func applicationPath(ownerID, applicationID string) string {
+	return fmt.Sprintf("%s/%s", applicationsPath(ownerID), url.PathEscape(applicationID))
+}
+
+// List applications of an owner.
+func List(ctx context.Context, c *client.Client, ownerID string) client.Response[[]Application] {
+	return client.Get[[]Application](ctx, c, applicationsPath(ownerID))
+}
+
+// Get an application.
+func Get(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[Application] {
+	return client.Get[Application](ctx, c, applicationPath(ownerID, applicationID))
+}
+
+// Create an application, it is not deployed until code is pushed.
+func Create(ctx context.Context, c *client.Client, ownerID string, application ApplicationRequest) client.Response[Application] {
+	return client.Post[Application](ctx, c, applicationsPath(ownerID), application)
+}
+
+// Update an application.
+func Update(ctx context.Context, c *client.Client, ownerID, applicationID string, application ApplicationRequest) client.Response[Application] {
+	return client.Put[Application](ctx, c, applicationPath(ownerID, applicationID), application)
+}
+
+// Delete an application and stop its instances.
+func Delete(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[client.Nothing] {
+	return client.Delete[client.Nothing](ctx, c, applicationPath(ownerID, applicationID))
+}
+
+// Start a stopped application from the head of its branch.
+// The API starts applications by redeploying them, see Redeploy().
+func Start(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[Action] {
+	return Redeploy(ctx, c, ownerID, applicationID, DeployOptions{})
+}
+
+// Restart an application from the head of its branch, reusing the build cache.
+// The API restarts applications by redeploying them, see Redeploy().
+func Restart(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[Action] {
+	return Redeploy(ctx, c, ownerID, applicationID, DeployOptions{})
+}
+
+// Redeploy an application, with a given commit or without build cache.
+// With zero options, the head of its branch is deployed, reusing the build cache.
+func Redeploy(ctx context.Context, c *client.Client, ownerID, applicationID string, opts DeployOptions) client.Response[Action] {
+	query := url.Values{}
+
+	if opts.Commit != "" {
+		query.Set("commit", opts.Commit)
+	}
+
+	if opts.WithoutCache {
+		query.Set("useCache", "no")
+	}
+
+	path := applicationPath(ownerID, applicationID) + "/instances"
+
+	return client.Post[Action](ctx, c, path, nil, client.WithQuery(query))
+}
+
+// Stop all instances of an application.
+func Stop(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[Action] {
+	return client.Delete[Action](ctx, c, applicationPath(ownerID, applicationID)+"/instances")
+}
+
+// Scale change instances count and flavors, they apply on next deployment.
+func Scale(ctx context.Context, c *client.Client, ownerID, applicationID string, scaling Scaling) client.Response[Application] {
+	return client.Put[Application](ctx, c, applicationPath(ownerID, applicationID), scaling)
+}
+
+// ListBranches of the application git repository.
+func ListBranches(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[[]string] {
+	return client.Get[[]string](ctx, c, applicationPath(ownerID, applicationID)+"/branches")
+}
+
+// SetBranch change the deployed branch.
+func SetBranch(ctx context.Context, c *client.Client, ownerID, applicationID, branch string) client.Response[client.Nothing] {
+	path := applicationPath(ownerID, applicationID) + "/branch"
+
+	return client.Put[client.Nothing](ctx, c, path, map[string]string{"branch": branch})
+}
+
+// ListTags of an application.
+func ListTags(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[[]string] {
+	return client.Get[[]string](ctx, c, applicationPath(ownerID, applicationID)+"/tags")
+}
+
+// AddTag to an application, returns all its tags.
+func AddTag(ctx context.Context, c *client.Client, ownerID, applicationID, tag string) client.Response[[]string] {
+	path := fmt.Sprintf("%s/tags/%s", applicationPath(ownerID, applicationID), url.PathEscape(tag))
+
+	return client.Put[[]string](ctx, c, path, nil)
+}
+
+// RemoveTag from an application, returns remaining tags.
+func RemoveTag(ctx context.Context, c *client.Client, ownerID, applicationID, tag string) client.Response[[]string] {
+	path := fmt.Sprintf("%s/tags/%s", applicationPath(ownerID, applicationID), url.PathEscape(tag))
+
+	return client.Delete[[]string](ctx, c, path)
+}
+
+// GetExposedConfig returns variables exposed to applications depending on this one.
+func GetExposedConfig(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[map[string]string] {
+	return client.Get[map[string]string](ctx, c, applicationPath(ownerID, applicationID)+"/exposed_env")
+}
+
+// SetExposedConfig replace variables exposed to applications depending on this one.
+func SetExposedConfig(ctx context.Context, c *client.Client, ownerID, applicationID string, config map[string]string) client.Response[client.Nothing] {
+	return client.Put[client.Nothing](ctx, c, applicationPath(ownerID, applicationID)+"/exposed_env", config)
+}
+
+// ListInstanceTypes applications can be created with, with their flavors.
+func ListInstanceTypes(ctx context.Context, c *client.Client) client.Response[[]InstanceType] {
+	return client.Get[[]InstanceType](ctx, c, "/v2/products/instances")
+}
+
+// ListZones applications can be deployed in.
+func ListZones(ctx context.Context, c *client.Client) client.Response[[]Zone] {
+	return client.Get[[]Zone](ctx, c, "/v2/products/zones")
+}
@@ -0,0 +1,303 @@
+package applications_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"go.clever-cloud.dev/client/applications"
+	"go.clever-cloud.dev/client/internal/clienttest"
+)
+
+func Test_List(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/self/applications",
+		Response: `[{"id":"app_1","name":"mine"}]`,
+	})
+
+	if apps := clienttest.Items(t, applications.List(context.Background(), c, ""), 1); apps[0].ID != "app_1" {
+		t.Errorf("unexpected applications: %+v", apps)
+	}
+}
+
+func Test_Get(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/organisations/orga_1/applications/app_2",
+		Response: `{"id":"app_2","name":"api","state":"SHOULD_BE_UP","branch":"main"}`,
+	})
+
+	app := clienttest.Payload(t, applications.Get(context.Background(), c, "orga_1", "app_2"))
+	if app.ID != "app_2" || app.State != "SHOULD_BE_UP" || app.Branch == nil || *app.Branch != "main" {
+		t.Errorf("unexpected application: %+v", app)
+	}
+}
+
+func Test_Create(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodPost,
+		Path:     "/v2/organisations/orga_1/applications",
+		Response: `{"id":"app_2","name":"api","instance":{"type":"node","minFlavor":{"name":"XS"}}}`,
+		Check: func(t *testing.T, r *http.Request) {
+			var body map[string]interface{}
+			clienttest.DecodeBody(t, r, &body)
+
+			expected := map[string]interface{}{"name": "api", "instanceType": "node"}
+			if !reflect.DeepEqual(body, expected) {
+				t.Errorf("expect %+v to be sent, got %+v", expected, body)
+			}
+		},
+	})
+
+	req := applications.ApplicationRequest{Name: "api", InstanceType: "node"}
+
+	app := clienttest.Payload(t, applications.Create(context.Background(), c, "orga_1", req))
+	if app.Instance.MinFlavor.Name != "XS" {
+		t.Errorf("unexpected application: %+v", app)
+	}
+}
+
+func Test_Update(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodPut,
+		Path:     "/v2/organisations/orga_1/applications/app_2",
+		Response: `{"id":"app_2","name":"api","description":"public API","stickySessions":true}`,
+		Check: func(t *testing.T, r *http.Request) {
+			var body map[string]interface{}
+			clienttest.DecodeBody(t, r, &body)
+
+			// empty fields are left untouched
+			expected := map[string]interface{}{"description": "public API", "stickySessions": true}
+			if !reflect.DeepEqual(body, expected) {
+				t.Errorf("expect %+v to be sent, got %+v", expected, body)
+			}
+		},
+	})
+
+	sticky := true
+	req := applications.ApplicationRequest{Description: "public API", StickySessions: &sticky}
+
+	app := clienttest.Payload(t, applications.Update(context.Background(), c, "orga_1", "app_2", req))
+	if app.Description != "public API" || !app.StickySessions {
+		t.Errorf("unexpected application: %+v", app)
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method: http.MethodDelete,
+		Path:   "/v2/organisations/orga_1/applications/app_2",
+	})
+
+	clienttest.Payload(t, applications.Delete(context.Background(), c, "orga_1", "app_2"))
+}
+
+func Test_StartRestart(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodPost,
+		Path:     "/v2/organisations/orga_1/applications/app_2/instances",
+		Response: `{"id":200,"type":"success","deploymentId":"deployment_1"}`,
+		Check: func(t *testing.T, r *http.Request) {
+			// the head of the branch is deployed with the build cache
+			if r.URL.RawQuery != "" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+		},
+	})
+	ctx := context.Background()
+
+	if action := clienttest.Payload(t, applications.Start(ctx, c, "orga_1", "app_2")); action.DeploymentID != "deployment_1" {
+		t.Errorf("unexpected action: %+v", action)
+	}
+
+	if action := clienttest.Payload(t, applications.Restart(ctx, c, "orga_1", "app_2")); action.DeploymentID != "deployment_1" {
+		t.Errorf("unexpected action: %+v", action)
+	}
+}
+
+func Test_Redeploy(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodPost,
+		Path:     "/v2/organisations/orga_1/applications/app_2/instances",
+		Response: `{"id":200,"message":"The application has successfully been queued for redeploy.","type":"success","deploymentId":"deployment_1"}`,
+		Check: func(t *testing.T, r *http.Request) {
+			if r.URL.Query().Get("commit") != "abc" || r.URL.Query().Get("useCache") != "no" {
+				t.Errorf("unexpected redeploy query: %s", r.URL.RawQuery)
+			}
+		},
+	})
+
+	opts := applications.DeployOptions{Commit: "abc", WithoutCache: true}
+
+	action := clienttest.Payload(t, applications.Redeploy(context.Background(), c, "orga_1", "app_2", opts))
+	if action.DeploymentID != "deployment_1" {
+		t.Errorf("unexpected action: %+v", action)
+	}
+}
+
+func Test_Stop(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodDelete,
+		Path:     "/v2/organisations/orga_1/applications/app_2/instances",
+		Response: `{"id":200,"type":"success"}`,
+	})
+
+	clienttest.Payload(t, applications.Stop(context.Background(), c, "orga_1", "app_2"))
+}
+
+func Test_Scale(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodPut,
+		Path:     "/v2/organisations/orga_1/applications/app_2",
+		Response: `{"id":"app_2","instance":{"maxInstances":4,"maxFlavor":{"name":"M"}}}`,
+		Check: func(t *testing.T, r *http.Request) {
+			var body map[string]interface{}
+			clienttest.DecodeBody(t, r, &body)
+
+			// the endpoint updates the whole application, other fields must be left out
+			expected := map[string]interface{}{"maxInstances": float64(4), "maxFlavor": "M"}
+			if !reflect.DeepEqual(body, expected) {
+				t.Errorf("expect only scaling fields %+v to be sent, got %+v", expected, body)
+			}
+		},
+	})
+
+	scaling := applications.Scaling{MaxInstances: 4, MaxFlavor: "M"}
+
+	app := clienttest.Payload(t, applications.Scale(context.Background(), c, "orga_1", "app_2", scaling))
+	if app.Instance.MaxInstances != 4 {
+		t.Errorf("unexpected application: %+v", app)
+	}
+}
+
+func Test_Tags(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t,
+		clienttest.Endpoint{Method: http.MethodGet, Path: "/v2/organisations/orga_1/applications/app_2/tags", Response: `["prod","eu"]`},
+		clienttest.Endpoint{Method: http.MethodPut, Path: "/v2/organisations/orga_1/applications/app_2/tags/prod", Response: `["prod"]`},
+		clienttest.Endpoint{Method: http.MethodDelete, Path: "/v2/organisations/orga_1/applications/app_2/tags/prod", Response: `[]`},
+	)
+	ctx := context.Background()
+
+	clienttest.Items(t, applications.ListTags(ctx, c, "orga_1", "app_2"), 2)
+
+	if tags := clienttest.Items(t, applications.AddTag(ctx, c, "orga_1", "app_2", "prod"), 1); tags[0] != "prod" {
+		t.Errorf("unexpected tags: %v", tags)
+	}
+
+	clienttest.Items(t, applications.RemoveTag(ctx, c, "orga_1", "app_2", "prod"), 0)
+}
+
+func Test_Branches(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t,
+		clienttest.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/v2/organisations/orga_1/applications/app_2/branches",
+			Response: `["main","feature"]`,
+		},
+		clienttest.Endpoint{
+			Method: http.MethodPut,
+			Path:   "/v2/organisations/orga_1/applications/app_2/branch",
+			Check: func(t *testing.T, r *http.Request) {
+				var body map[string]string
+				clienttest.DecodeBody(t, r, &body)
+
+				if body["branch"] != "feature" {
+					t.Errorf("unexpected branch request: %+v", body)
+				}
+			},
+		},
+	)
+	ctx := context.Background()
+
+	if branches := clienttest.Items(t, applications.ListBranches(ctx, c, "orga_1", "app_2"), 2); branches[1] != "feature" {
+		t.Errorf("unexpected branches: %v", branches)
+	}
+
+	clienttest.Payload(t, applications.SetBranch(ctx, c, "orga_1", "app_2", "feature"))
+}
+
+func Test_ExposedConfig(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t,
+		clienttest.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/v2/organisations/orga_1/applications/app_2/exposed_env",
+			Response: `{"API_URL":"https://api.example.com"}`,
+		},
+		clienttest.Endpoint{
+			Method: http.MethodPut,
+			Path:   "/v2/organisations/orga_1/applications/app_2/exposed_env",
+			Check: func(t *testing.T, r *http.Request) {
+				var body map[string]string
+				clienttest.DecodeBody(t, r, &body)
+
+				expected := map[string]string{"API_URL": "https://api.example.org"}
+				if !reflect.DeepEqual(body, expected) {
+					t.Errorf("expect %+v to be sent, got %+v", expected, body)
+				}
+			},
+		},
+	)
+	ctx := context.Background()
+
+	config := clienttest.Payload(t, applications.GetExposedConfig(ctx, c, "orga_1", "app_2"))
+	if (*config)["API_URL"] != "https://api.example.com" {
+		t.Errorf("unexpected exposed config: %+v", *config)
+	}
+
+	clienttest.Payload(t, applications.SetExposedConfig(ctx, c, "orga_1", "app_2", map[string]string{"API_URL": "https://api.example.org"}))
+}
+
+func Test_ListZones(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/products/zones",
+		Response: `[{"id":"z_1","name":"par","city":"Paris","countryCode":"FR","tags":["region:eu"]}]`,
+	})
+
+	if zones := clienttest.Items(t, applications.ListZones(context.Background(), c), 1); zones[0].Name != "par" || zones[0].CountryCode != "FR" {
+		t.Errorf("unexpected zones: %+v", zones)
+	}
+}
+
+func Test_ListInstanceTypes(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/products/instances",
+		Response: `[{"type":"node","version":"20","name":"Node","enabled":true,"flavors":[{"name":"XS"},{"name":"S"}],"defaultFlavor":{"name":"XS"}}]`,
+	})
+
+	types := clienttest.Items(t, applications.ListInstanceTypes(context.Background(), c), 1)
+	if types[0].Type != "node" || len(types[0].Flavors) != 2 || types[0].DefaultFlavor.Name != "XS" {
+		t.Errorf("unexpected instance types: %+v", types)
+	}
+}
@@ -0,0 +1,122 @@
+package applications
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.clever-cloud.dev/client"
+)
+
+// LinkedAddonEnv holds variables an add-on exposes to an application it is linked to.
+type LinkedAddonEnv struct {
+	AddonID   string        `json:"addon_id"`
+	AddonName string        `json:"addon_name"`
+	Env       []EnvVariable `json:"env"`
+}
+
+// EnvDiff lists variable names to change to go from an environment to another.
+type EnvDiff struct {
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+// Empty tells if both environments are the same.
+func (d EnvDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// String describe the diff with names only, values are never shown.
+func (d EnvDiff) String() string {
+	changes := []string{}
+
+	for _, name := range d.Added {
+		changes = append(changes, "+"+name)
+	}
+
+	for _, name := range d.Changed {
+		changes = append(changes, "~"+name)
+	}
+
+	for _, name := range d.Removed {
+		changes = append(changes, "-"+name)
+	}
+
+	return strings.Join(changes, " ")
+}
+
+// EnvToMap index variables by name.
+func EnvToMap(variables []EnvVariable) map[string]string {
+	env := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		env[variable.Name] = variable.Value
+	}
+
+	return env
+}
+
+// Diff returns variables to add, change and remove to go from current to desired, sorted by name.
+func Diff(current, desired map[string]string) EnvDiff {
+	diff := EnvDiff{}
+
+	for name, value := range desired {
+		currentValue, ok := current[name]
+
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, name)
+		case currentValue != value:
+			diff.Changed = append(diff.Changed, name)
+		}
+	}
+
+	for name := range current {
+		if _, ok := desired[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Removed)
+
+	return diff
+}
+
+// GetEnv returns variables set by users on an application.
+func GetEnv(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[[]EnvVariable] {
+	return client.Get[[]EnvVariable](ctx, c, applicationPath(ownerID, applicationID)+"/env")
+}
+
+// ReplaceEnv replace all variables of an application at once, missing ones are removed.
+func ReplaceEnv(ctx context.Context, c *client.Client, ownerID, applicationID string, env map[string]string) client.Response[client.Nothing] {
+	return client.Put[client.Nothing](ctx, c, applicationPath(ownerID, applicationID)+"/env", env)
+}
+
+// GetLinkedAddonsEnv returns variables exposed by add-ons linked to an application.
+func GetLinkedAddonsEnv(ctx context.Context, c *client.Client, ownerID, applicationID string) client.Response[[]LinkedAddonEnv] {
+	return client.Get[[]LinkedAddonEnv](ctx, c, applicationPath(ownerID, applicationID)+"/addons/env")
+}
+
+// ApplyEnv make application variables match the desired ones, in a single replacement.
+// Nothing is sent if they already match. Returns what changed.
+func ApplyEnv(ctx context.Context, c *client.Client, ownerID, applicationID string, desired map[string]string) client.Response[EnvDiff] {
+	current := GetEnv(ctx, c, ownerID, applicationID)
+	if current.HasError() {
+		return client.Map(current, func(*[]EnvVariable) (EnvDiff, error) { return EnvDiff{}, nil })
+	}
+
+	diff := Diff(EnvToMap(*current.Payload()), desired)
+	if diff.Empty() {
+		c.Logger().Debugf("ENV:\t%s\t->\tup to date", applicationID)
+
+		return client.Map(current, func(*[]EnvVariable) (EnvDiff, error) { return diff, nil })
+	}
+
+	c.Logger().Infof("ENV:\t%s\t->\t%s", applicationID, diff)
+
+	res := ReplaceEnv(ctx, c, ownerID, applicationID, desired)
+
+	return client.Map(res, func(*client.Nothing) (EnvDiff, error) { return diff, nil })
+}
@@ -0,0 +1,150 @@
+package applications_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.clever-cloud.dev/client"
+	"go.clever-cloud.dev/client/applications"
+	"go.clever-cloud.dev/client/internal/clienttest"
+)
+
+func Test_Diff(t *testing.T) {
+	t.Parallel()
+
+	diff := applications.Diff(
+		map[string]string{"KEPT": "1", "CHANGED": "old", "REMOVED": "x"},
+		map[string]string{"KEPT": "1", "CHANGED": "new", "B_ADDED": "y", "A_ADDED": "z"},
+	)
+
+	expected := applications.EnvDiff{
+		Added:   []string{"A_ADDED", "B_ADDED"},
+		Changed: []string{"CHANGED"},
+		Removed: []string{"REMOVED"},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("expect %+v, got %+v", expected, diff)
+	}
+
+	if diff.String() != "+A_ADDED +B_ADDED ~CHANGED -REMOVED" {
+		t.Errorf("unexpected diff description: %s", diff)
+	}
+
+	if !applications.Diff(map[string]string{"A": "1"}, map[string]string{"A": "1"}).Empty() {
+		t.Error("expect an empty diff")
+	}
+}
+
+func Test_ApplyEnv(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+
+	env := map[string]string{"DATABASE_PASSWORD": "hunter2"}
+	puts := 0
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			variables := []applications.EnvVariable{}
+			for name, value := range env {
+				variables = append(variables, applications.EnvVariable{Name: name, Value: value})
+			}
+
+			_ = json.NewEncoder(w).Encode(variables)
+		case http.MethodPut:
+			puts++
+			env = map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&env)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer api.Close()
+
+	var logs bytes.Buffer
+
+	logger := logrus.New()
+	logger.SetOutput(&logs)
+	logger.SetLevel(logrus.DebugLevel)
+
+	c := client.New(client.WithEndpoint(api.URL), client.WithLogger(logger))
+	desired := map[string]string{"DATABASE_PASSWORD": "correct horse", "API_KEY": "s3cr3t"}
+
+	res := applications.ApplyEnv(context.Background(), c, "orga_1", "app_1", desired)
+	if res.HasError() || len(res.Payload().Added) != 1 || len(res.Payload().Changed) != 1 {
+		t.Fatalf("unexpected apply response: %+v (%v)", res.Payload(), res.Error())
+	}
+
+	res = applications.ApplyEnv(context.Background(), c, "orga_1", "app_1", desired)
+	if res.HasError() || !res.Payload().Empty() {
+		t.Fatalf("expect nothing to change: %+v (%v)", res.Payload(), res.Error())
+	}
+
+	if puts != 1 {
+		t.Errorf("expect a single replacement, got %d", puts)
+	}
+
+	for _, secret := range []string{"hunter2", "correct horse", "s3cr3t"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("secret %q leaked in logs:\n%s", secret, logs.String())
+		}
+	}
+}
+
+func Test_GetEnv(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/organisations/orga_1/applications/app_1/env",
+		Response: `[{"name":"PORT","value":"8080"}]`,
+	})
+
+	env := applications.EnvToMap(clienttest.Items(t, applications.GetEnv(context.Background(), c, "orga_1", "app_1"), 1))
+	if env["PORT"] != "8080" {
+		t.Errorf("unexpected env: %v", env)
+	}
+}
+
+func Test_ReplaceEnv(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method: http.MethodPut,
+		Path:   "/v2/organisations/orga_1/applications/app_1/env",
+		Check: func(t *testing.T, r *http.Request) {
+			var body map[string]string
+			if clienttest.DecodeBody(t, r, &body); !reflect.DeepEqual(body, map[string]string{"PORT": "8080"}) {
+				t.Errorf("unexpected env: %v", body)
+			}
+		},
+	})
+
+	clienttest.Payload(t, applications.ReplaceEnv(context.Background(), c, "orga_1", "app_1", map[string]string{"PORT": "8080"}))
+}
+
+func Test_GetLinkedAddonsEnv(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/self/applications/app_1/addons/env",
+		Response: `[{"addon_id":"addon_1","addon_name":"db","env":[{"name":"POSTGRESQL_ADDON_HOST","value":"db.example.com"}]}]`,
+	})
+
+	addons := clienttest.Items(t, applications.GetLinkedAddonsEnv(context.Background(), c, "", "app_1"), 1)
+	if addons[0].AddonID != "addon_1" || applications.EnvToMap(addons[0].Env)["POSTGRESQL_ADDON_HOST"] != "db.example.com" {
+		t.Errorf("unexpected linked add-ons env: %+v", addons)
+	}
+}
@@ -0,0 +1,178 @@
+package applications
+
+// Application is a deployable application.
+type Application struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	Description    string        `json:"description"`
+	Zone           string        `json:"zone"`
+	Instance       Instance      `json:"instance"`
+	Deployment     Deployment    `json:"deployment"`
+	Vhosts         []Vhost       `json:"vhosts"`
+	CreationDate   int64         `json:"creationDate"`
+	LastDeploy     int           `json:"last_deploy"`
+	Archived       bool          `json:"archived"`
+	StickySessions bool          `json:"stickySessions"`
+	Homogeneous    bool          `json:"homogeneous"`
+	Favourite      bool          `json:"favourite"`
+	CancelOnPush   bool          `json:"cancelOnPush"`
+	WebhookURL     *string       `json:"webhookUrl"`
+	WebhookSecret  *string       `json:"webhookSecret"`
+	SeparateBuild  bool          `json:"separateBuild"`
+	BuildFlavor    *Flavor       `json:"buildFlavor"`
+	OwnerID        string        `json:"ownerId"`
+	State          string        `json:"state"`
+	CommitID       *string       `json:"commitId"`
+	Appliance      *string       `json:"appliance"`
+	Branch         *string       `json:"branch"`
+	ForceHTTPS     string        `json:"forceHttps"`
+	Env            []EnvVariable `json:"env"`
+}
+
+// Instance describe the runtime of an application and its scaling settings.
+type Instance struct {
+	Type                string            `json:"type"`
+	Version             string            `json:"version"`
+	Variant             Variant           `json:"variant"`
+	MinInstances        int               `json:"minInstances"`
+	MaxInstances        int               `json:"maxInstances"`
+	MaxAllowedInstances int               `json:"maxAllowedInstances"`
+	MinFlavor           Flavor            `json:"minFlavor"`
+	MaxFlavor           Flavor            `json:"maxFlavor"`
+	Flavors             []Flavor          `json:"flavors"`
+	DefaultEnv          map[string]string `json:"defaultEnv"`
+	Lifetime            string            `json:"lifetime"`
+	InstanceAndVersion  string            `json:"instanceAndVersion"`
+}
+
+type Variant struct {
+	ID         string `json:"id"`
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	DeployType string `json:"deployType"`
+	Logo       string `json:"logo"`
+}
+
+// Flavor is a size of instance.
+type Flavor struct {
+	Name            string  `json:"name"`
+	Mem             int     `json:"mem"`
+	CPUs            int     `json:"cpus"`
+	GPUs            int     `json:"gpus"`
+	Disk            *int    `json:"disk"`
+	Price           float64 `json:"price"`
+	PriceID         string  `json:"price_id"`
+	Available       bool    `json:"available"`
+	Microservice    bool    `json:"microservice"`
+	MachineLearning bool    `json:"machine_learning"`
+	Nice            int     `json:"nice"`
+	Memory          Memory  `json:"memory"`
+}
+
+type Memory struct {
+	Unit      string `json:"unit"`
+	Value     int64  `json:"value"`
+	Formatted string `json:"formatted"`
+}
+
+type Deployment struct {
+	Shutdownable bool   `json:"shutdownable"`
+	Type         string `json:"type"`
+	RepoState    string `json:"repoState"`
+	URL          string `json:"url"`
+	HTTPURL      string `json:"httpUrl"`
+}
+
+type Vhost struct {
+	Fqdn string `json:"fqdn"`
+}
+
+type EnvVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ApplicationRequest holds fields to set on creation or update.
+// On update, empty fields are left untouched.
+type ApplicationRequest struct {
+	Name             string   `json:"name,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	Zone             string   `json:"zone,omitempty"`
+	Deploy           string   `json:"deploy,omitempty"`
+	InstanceType     string   `json:"instanceType,omitempty"`
+	InstanceVersion  string   `json:"instanceVersion,omitempty"`
+	InstanceVariant  string   `json:"instanceVariant,omitempty"`
+	InstanceLifetime string   `json:"instanceLifetime,omitempty"`
+	MinInstances     int      `json:"minInstances,omitempty"`
+	MaxInstances     int      `json:"maxInstances,omitempty"`
+	MinFlavor        string   `json:"minFlavor,omitempty"`
+	MaxFlavor        string   `json:"maxFlavor,omitempty"`
+	BuildFlavor      string   `json:"buildFlavor,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+	Branch           string   `json:"branch,omitempty"`
+	ForceHTTPS       string   `json:"forceHttps,omitempty"`
+	Shutdownable     *bool    `json:"shutdownable,omitempty"`
+	Archived         *bool    `json:"archived,omitempty"`
+	StickySessions   *bool    `json:"stickySessions,omitempty"`
+	Homogeneous      *bool    `json:"homogeneous,omitempty"`
+	SeparateBuild    *bool    `json:"separateBuild,omitempty"`
+	CancelOnPush     *bool    `json:"cancelOnPush,omitempty"`
+	Favourite        *bool    `json:"favourite,omitempty"`
+}
+
+// Scaling settings of an application, empty fields are left untouched.
+// Flavors are referenced by name, like "XS" or "M".
+type Scaling struct {
+	MinInstances int    `json:"minInstances,omitempty"`
+	MaxInstances int    `json:"maxInstances,omitempty"`
+	MinFlavor    string `json:"minFlavor,omitempty"`
+	MaxFlavor    string `json:"maxFlavor,omitempty"`
+	BuildFlavor  string `json:"buildFlavor,omitempty"`
+}
+
+// InstanceType is a runtime applications can be created with.
+type InstanceType struct {
+	Type          string   `json:"type"`
+	Version       string   `json:"version"`
+	Name          string   `json:"name"`
+	Variant       Variant  `json:"variant"`
+	Description   string   `json:"description"`
+	Enabled       bool     `json:"enabled"`
+	ComingSoon    bool     `json:"comingSoon"`
+	MaxInstances  int      `json:"maxInstances"`
+	Tags          []string `json:"tags"`
+	Deployments   []string `json:"deployments"`
+	Flavors       []Flavor `json:"flavors"`
+	DefaultFlavor Flavor   `json:"defaultFlavor"`
+	BuildFlavor   Flavor   `json:"buildFlavor"`
+}
+
+// Zone is a region applications and add-ons can be deployed in.
+type Zone struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Country     string   `json:"country"`
+	CountryCode string   `json:"countryCode"`
+	City        string   `json:"city"`
+	DisplayName string   `json:"displayName"`
+	Lat         float64  `json:"lat"`
+	Lon         float64  `json:"lon"`
+	OutboundIPs []string `json:"outboundIPs"`
+	Tags        []string `json:"tags"`
+}
+
+// DeployOptions tells which commit to deploy and how.
+type DeployOptions struct {
+	// Deploy this commit instead of the branch head
+	Commit string
+	// Rebuild from scratch, without the build cache
+	WithoutCache bool
+}
+
+// Action is the API answer to a lifecycle action.
+type Action struct {
+	ID           int    `json:"id"`
+	Message      string `json:"message"`
+	Type         string `json:"type"`
+	DeploymentID string `json:"deploymentId"`
+}
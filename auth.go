@@ -1,6 +1,9 @@
 package client
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 // Authenticator is called to authenticate an HTTP request.
 type Authenticator interface {
@@ -10,3 +13,46 @@ type Authenticator interface {
 	// Return current user credentials (oauth1 user token, oauth1 user secret)
 	//Oauth1UserCredentials() (string, string)
 }
+
+// ContextAuthenticator is an Authenticator which can fail to sign a request.
+// It is used instead of Sign() when implemented.
+type ContextAuthenticator interface {
+	Authenticator
+
+	// Add authentication stuff on an HTTP request, the request is not sent on error
+	SignRequest(ctx context.Context, req *http.Request) error
+}
+
+// Refresher is implemented by authenticators able to renew their credentials.
+// On a 401 response, credentials are refreshed and the request is sent once more.
+type Refresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// legacyAuthenticator adapt an Authenticator which cannot fail.
+type legacyAuthenticator struct {
+	Authenticator
+}
+
+func (a legacyAuthenticator) SignRequest(ctx context.Context, req *http.Request) error {
+	a.Sign(req)
+
+	return nil
+}
+
+func asContextAuthenticator(auth Authenticator) ContextAuthenticator {
+	if ca, ok := auth.(ContextAuthenticator); ok {
+		return ca
+	}
+
+	return legacyAuthenticator{auth}
+}
+
+// signError is returned when a request cannot be signed, such requests are not retried.
+type signError struct {
+	error
+}
+
+func (e *signError) Unwrap() error {
+	return e.error
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -9,10 +10,22 @@ type BearerConfig struct {
 	Token string
 }
 
+// Sign set the bearer token, an empty token sends the request without authentication.
 func (auth *BearerConfig) Sign(req *http.Request) {
+	if auth == nil || auth.Token == "" {
+		return
+	}
+
 	value := fmt.Sprintf("Bearer %s", auth.Token)
 
 	req.
 		Header.
 		Set("Authorization", value)
 }
+
+// SignRequest set the bearer token, an empty token sends the request without authentication.
+func (auth *BearerConfig) SignRequest(ctx context.Context, req *http.Request) error {
+	auth.Sign(req)
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// APITokenRequest describe an API token to create.
+// Tokens are created with user credentials, not with an existing token.
+type APITokenRequest struct {
+	Email          string     `json:"email"`
+	Password       string     `json:"password"`
+	MFACode        string     `json:"mfaCode,omitempty"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description,omitempty"`
+	ExpirationDate *time.Time `json:"expirationDate,omitempty"` // the API default applies when nil
+}
+
+// APIToken is a bearer token usable against the bridge API.
+type APIToken struct {
+	ID             string    `json:"apiTokenId"`
+	Token          string    `json:"apiToken,omitempty"` // only given on creation
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	CreationDate   time.Time `json:"creationDate"`
+	ExpirationDate time.Time `json:"expirationDate"`
+	State          string    `json:"state"`
+}
+
+// BearerConfig returns credentials using this token.
+func (t *APIToken) BearerConfig() *BearerConfig {
+	return &BearerConfig{Token: t.Token}
+}
+
+// Expired tells if the token reached its expiration date.
+func (t *APIToken) Expired() bool {
+	return !t.ExpirationDate.IsZero() && time.Now().After(t.ExpirationDate)
+}
+
+// Create an API token, the secret token is only available in this response.
+// The client must be authenticated with OAuth1 credentials, or not at all
+// (WithBearerAuth("") without CLEVER_API_TOKEN sends it without authentication).
+func CreateAPIToken(ctx context.Context, c *Client, token APITokenRequest) Response[APIToken] {
+	return Post[APIToken](ctx, c, "/api-tokens", token, onBridge())
+}
+
+// List API tokens of the current user.
+func ListAPITokens(ctx context.Context, c *Client) Response[[]APIToken] {
+	return Get[[]APIToken](ctx, c, "/api-tokens", onBridge())
+}
+
+// Revoke an API token.
+func RevokeAPIToken(ctx context.Context, c *Client, tokenID string) Response[Nothing] {
+	path := fmt.Sprintf("/api-tokens/%s", url.PathEscape(tokenID))
+
+	return Delete[Nothing](ctx, c, path, onBridge())
+}
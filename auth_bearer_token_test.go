@@ -0,0 +1,106 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_APITokens(t *testing.T) {
+	t.Parallel()
+
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api-tokens":
+			var req client.APITokenRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email != "me@example.com" || req.MFACode != "" {
+				t.Errorf("unexpected token request: %+v (%v)", req, err)
+			}
+
+			_, _ = w.Write([]byte(`{"apiTokenId":"tok_1","apiToken":"secret","name":"ci","expirationDate":"2025-06-06T00:00:00.000Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api-tokens":
+			_, _ = w.Write([]byte(`[{"apiTokenId":"tok_1","name":"ci","state":"ACTIVE"}]`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api-tokens/tok_1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer bridge.Close()
+
+	c := client.New(
+		client.WithEndpoint("http://api.invalid"),
+		client.WithBridgeEndpoint(bridge.URL),
+	)
+
+	expiration := time.Now().Add(time.Hour)
+
+	created := client.CreateAPIToken(context.Background(), c, client.APITokenRequest{
+		Email:          "me@example.com",
+		Password:       "password",
+		Name:           "ci",
+		ExpirationDate: &expiration,
+	})
+	if created.HasError() {
+		t.Fatalf("unexpected error: %v", created.Error())
+	}
+
+	if conf := created.Payload().BearerConfig(); conf.Token != "secret" {
+		t.Errorf("expect created token, got %+v", created.Payload())
+	}
+
+	listed := client.ListAPITokens(context.Background(), c)
+	if listed.HasError() || len(*listed.Payload()) != 1 {
+		t.Fatalf("unexpected list response: %+v (%v)", listed.Payload(), listed.Error())
+	}
+
+	if revoked := client.RevokeAPIToken(context.Background(), c, "tok_1"); revoked.HasError() {
+		t.Errorf("unexpected error: %v", revoked.Error())
+	}
+}
+
+func Test_APITokenRequest_noExpirationDate(t *testing.T) {
+	t.Parallel()
+
+	b, err := json.Marshal(client.APITokenRequest{Email: "me@example.com", Password: "password", Name: "ci"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(string(b), "expirationDate") {
+		t.Errorf("expect no expiration date to be sent, got %s", b)
+	}
+}
+
+func Test_CreateAPIToken_emptyBearer(t *testing.T) {
+	t.Setenv("CLEVER_API_TOKEN", "")
+
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("expect an unauthenticated request, got Authorization: %s", auth)
+		}
+
+		_, _ = w.Write([]byte(`{"apiTokenId":"tok_1","apiToken":"secret"}`))
+	}))
+	defer bridge.Close()
+
+	c := client.New(
+		client.WithBridgeEndpoint(bridge.URL),
+		client.WithBearerAuth(""),
+	)
+
+	res := client.CreateAPIToken(context.Background(), c, client.APITokenRequest{
+		Email:    "me@example.com",
+		Password: "password",
+		Name:     "ci",
+	})
+	if res.HasError() {
+		t.Errorf("unexpected error: %v", res.Error())
+	}
+}
@@ -4,16 +4,24 @@ package client
 // But using a HMAC-SHA512 algorithm
 
 import (
+	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha512"
 	"encoding/base64"
+	"io"
 	"math/big"
+	"mime"
+	"net"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // OAuth1Config own credentials to contact CleverCloud API.
@@ -24,41 +32,221 @@ type OAuth1Config struct {
 	AccessSecret   string `json:"secret"`
 }
 
+// withDefaultConsumer use clever-tools consumer if none is set.
+func (auth *OAuth1Config) withDefaultConsumer() *OAuth1Config {
+	if auth.ConsumerKey == "" {
+		auth.ConsumerKey = OAUTH_CONSUMER_KEY
+	}
+
+	if auth.ConsumerSecret == "" {
+		auth.ConsumerSecret = OAUTH_CONSUMER_SECRET
+	}
+
+	return auth
+}
+
 // Sign an HTTP request with the given OAuth1 signature.
+// Query parameters and form-encoded body parameters are part of the signature.
 func (auth *OAuth1Config) Sign(req *http.Request) {
 	if auth == nil {
 		return
 	}
 
-	authHeader := auth.buildOAuth1Header(req.Method, req.URL.String(), map[string]string{})
+	if err := auth.sign(req); err != nil {
+		// request is signed without its body parameters, the API will reject it
+		req.Header.Set("Authorization", auth.buildOAuth1Header(req.Method, req.URL, req.URL.Query()))
+	}
+}
+
+// SignRequest sign an HTTP request, fails if credentials are missing or the body cannot be read.
+func (auth *OAuth1Config) SignRequest(ctx context.Context, req *http.Request) error {
+	if auth == nil || auth.AccessToken == "" || auth.AccessSecret == "" {
+		return errors.New("missing OAuth1 credentials")
+	}
+
+	return auth.sign(req)
+}
+
+func (auth *OAuth1Config) sign(req *http.Request) error {
+	params, err := requestParameters(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot read request parameters")
+	}
+
+	authHeader := auth.buildOAuth1Header(req.Method, req.URL, params)
 	req.Header.Set("Authorization", authHeader)
+
+	return nil
+}
+
+// Params being any request parameter pairs (query and form-encoded body).
+func (auth OAuth1Config) buildOAuth1Header(method string, u *url.URL, params url.Values) string {
+	return auth.authorizationHeader(method, u, params, auth.protocolParameters())
 }
 
-// Params being any key-value url query parameter pairs.
-func (auth OAuth1Config) buildOAuth1Header(method, path string, params map[string]string) string {
+// protocolParameters returns the oauth_* parameters sent with each request.
+func (auth OAuth1Config) protocolParameters() url.Values {
 	vals := url.Values{}
-	vals.Add("oauth_nonce", auth.generateNonce())
-	vals.Add("oauth_consumer_key", auth.ConsumerKey)
-	vals.Add("oauth_signature_method", "HMAC-SHA512")
-	vals.Add("oauth_timestamp", strconv.Itoa(int(time.Now().Unix())))
-	vals.Add("oauth_token", auth.AccessToken)
-	vals.Add("oauth_version", "1.0")
+	vals.Set("oauth_nonce", auth.generateNonce())
+	vals.Set("oauth_consumer_key", auth.ConsumerKey)
+	vals.Set("oauth_signature_method", "HMAC-SHA512")
+	vals.Set("oauth_timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+	vals.Set("oauth_version", "1.0")
 
-	for k, v := range params {
-		vals.Add(k, v)
+	if auth.AccessToken != "" {
+		vals.Set("oauth_token", auth.AccessToken)
+	}
+
+	return vals
+}
+
+// authorizationHeader sign the request and format the Authorization header
+// https://www.rfc-editor.org/rfc/rfc5849#section-3.5.1
+func (auth OAuth1Config) authorizationHeader(method string, u *url.URL, params, oauthParams url.Values) string {
+	all := url.Values{}
+
+	for _, vals := range []url.Values{params, oauthParams} {
+		for k, values := range vals {
+			all[k] = append(all[k], values...)
+		}
 	}
-	// net/url package QueryEscape escapes " " into "+", this replaces it with the percentage encoding of " "
-	parameterString := strings.ReplaceAll(vals.Encode(), "+", "%20")
 
 	// Calculating Signature Base String and Signing Key
-	signatureBase := strings.ToUpper(method) + "&" + url.QueryEscape(strings.Split(path, "?")[0]) + "&" + url.QueryEscape(parameterString)
-	signingKey := url.QueryEscape(auth.ConsumerSecret) + "&" + url.QueryEscape(auth.AccessSecret)
-	signature := auth.calculateSignature(signatureBase, signingKey)
-
-	return "OAuth oauth_consumer_key=\"" + url.QueryEscape(vals.Get("oauth_consumer_key")) + "\", oauth_nonce=\"" + url.QueryEscape(vals.Get("oauth_nonce")) +
-		"\", oauth_signature=\"" + url.QueryEscape(signature) + "\", oauth_signature_method=\"" + url.QueryEscape(vals.Get("oauth_signature_method")) +
-		"\", oauth_timestamp=\"" + url.QueryEscape(vals.Get("oauth_timestamp")) + "\", oauth_token=\"" + url.QueryEscape(vals.Get("oauth_token")) +
-		"\", oauth_version=\"" + url.QueryEscape(vals.Get("oauth_version")) + "\""
+	signatureBase := signatureBaseString(method, u, all)
+	signingKey := percentEncode(auth.ConsumerSecret) + "&" + percentEncode(auth.AccessSecret)
+
+	signed := url.Values{}
+	for k, values := range oauthParams {
+		signed[k] = values
+	}
+
+	signed.Set("oauth_signature", auth.calculateSignature(signatureBase, signingKey))
+
+	keys := make([]string, 0, len(signed))
+	for k := range signed {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, percentEncode(k)+"=\""+percentEncode(signed.Get(k))+"\"")
+	}
+
+	return "OAuth " + strings.Join(pairs, ", ")
+}
+
+// requestParameters collect query parameters and form-encoded body parameters
+// https://www.rfc-editor.org/rfc/rfc5849#section-3.4.1.3.1
+func requestParameters(req *http.Request) (url.Values, error) {
+	params := req.URL.Query()
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return params, nil
+	}
+
+	// only form-encoded bodies are part of the signature
+	if mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mediaType != "application/x-www-form-urlencoded" {
+		return params, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+
+	// give back a fresh body to the transport
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for k, values := range form {
+		params[k] = append(params[k], values...)
+	}
+
+	return params, nil
+}
+
+// signatureBaseString https://www.rfc-editor.org/rfc/rfc5849#section-3.4.1
+func signatureBaseString(method string, u *url.URL, params url.Values) string {
+	return strings.ToUpper(method) + "&" + percentEncode(baseStringURI(u)) + "&" + percentEncode(normalizeParameters(params))
+}
+
+// baseStringURI https://www.rfc-editor.org/rfc/rfc5849#section-3.4.1.2
+func baseStringURI(u *url.URL) string {
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+
+	if port := u.Port(); port != "" &&
+		!(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host = net.JoinHostPort(host, port)
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	return scheme + "://" + host + path
+}
+
+// normalizeParameters https://www.rfc-editor.org/rfc/rfc5849#section-3.4.1.3.2
+func normalizeParameters(params url.Values) string {
+	pairs := make([][2]string, 0, len(params))
+
+	for k, values := range params {
+		if k == "oauth_signature" || k == "realm" {
+			continue
+		}
+
+		for _, v := range values {
+			pairs = append(pairs, [2]string{percentEncode(k), percentEncode(v)})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+
+		return pairs[i][1] < pairs[j][1]
+	})
+
+	encoded := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		encoded = append(encoded, pair[0]+"="+pair[1])
+	}
+
+	return strings.Join(encoded, "&")
+}
+
+// percentEncode escape everything but unreserved characters, using uppercase hex digits
+// https://www.rfc-editor.org/rfc/rfc5849#section-3.6
+func percentEncode(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0x0F])
+		}
+	}
+
+	return b.String()
 }
 
 func (auth OAuth1Config) calculateSignature(base, key string) string {
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// OAuth1Login drive the three-legged OAuth1 flow to get user credentials:
+// request token, user authorization in a browser, verifier captured by a loopback
+// callback server, then exchange for an access token.
+type OAuth1Login struct {
+	// Consumer credentials, default: OAUTH_CONSUMER_KEY and OAUTH_CONSUMER_SECRET
+	ConsumerKey    string
+	ConsumerSecret string
+	// API endpoint, default: API_ENDPOINT
+	Endpoint string
+	// Address of the loopback callback server, default: 127.0.0.1 on a random port
+	CallbackAddr string
+	// Called with the URL the user must visit, default: try to open a browser
+	OpenURL func(authorizeURL string) error
+	// Where to print instructions, default: os.Stderr
+	Out io.Writer
+	// HTTP client, default: http.DefaultClient
+	HTTPClient *http.Client
+	// Write credentials in clever-tools configuration file
+	Save bool
+}
+
+// Login run the OAuth1 flow and returns the user credentials.
+// It blocks until the user authorize the application or the context is done.
+func (l *OAuth1Login) Login(ctx context.Context) (*OAuth1Config, error) {
+	ctx = mustContext(ctx)
+	l.setDefaults()
+
+	listener, err := net.Listen("tcp", l.CallbackAddr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot start OAuth1 callback server")
+	}
+	defer listener.Close()
+
+	callbackURL := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	consumer := OAuth1Config{ConsumerKey: l.ConsumerKey, ConsumerSecret: l.ConsumerSecret}
+
+	requestToken, err := l.oauthRequest(ctx, "/v2/oauth/request_token", consumer, url.Values{"oauth_callback": {callbackURL}})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get OAuth1 request token")
+	}
+
+	consumer.AccessToken = requestToken.Get("oauth_token")
+	consumer.AccessSecret = requestToken.Get("oauth_token_secret")
+
+	if consumer.AccessToken == "" {
+		return nil, errors.New("no OAuth1 request token in API response")
+	}
+
+	verifiers := make(chan string, 1)
+	srv := &http.Server{
+		Handler:           l.callbackHandler(consumer.AccessToken, verifiers),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() { _ = srv.Serve(listener) }()
+	defer srv.Close()
+
+	authorizeURL := fmt.Sprintf("%s/v2/oauth/authorize?oauth_token=%s", l.Endpoint, url.QueryEscape(consumer.AccessToken))
+	fmt.Fprintf(l.Out, "Open this URL to log in to Clever Cloud:\n\n\t%s\n\n", authorizeURL)
+
+	if err := l.OpenURL(authorizeURL); err != nil {
+		fmt.Fprintf(l.Out, "Cannot open a browser (%s), please open the URL manually\n", err.Error())
+	}
+
+	var verifier string
+	select {
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "OAuth1 authorization was not completed")
+	case verifier = <-verifiers:
+	}
+
+	accessToken, err := l.oauthRequest(ctx, "/v2/oauth/access_token", consumer, url.Values{"oauth_verifier": {verifier}})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get OAuth1 access token")
+	}
+
+	conf := &OAuth1Config{
+		ConsumerKey:    l.ConsumerKey,
+		ConsumerSecret: l.ConsumerSecret,
+		AccessToken:    accessToken.Get("oauth_token"),
+		AccessSecret:   accessToken.Get("oauth_token_secret"),
+	}
+
+	if conf.AccessToken == "" || conf.AccessSecret == "" {
+		return nil, errors.New("no OAuth1 access token in API response")
+	}
+
+	if l.Save {
+		path, err := SaveOAuth1Config(conf)
+		if err != nil {
+			return conf, err
+		}
+
+		fmt.Fprintf(l.Out, "Credentials saved in '%s'\n", path)
+	}
+
+	return conf, nil
+}
+
+func (l *OAuth1Login) setDefaults() {
+	if l.ConsumerKey == "" {
+		l.ConsumerKey = OAUTH_CONSUMER_KEY
+		l.ConsumerSecret = OAUTH_CONSUMER_SECRET
+	}
+
+	if l.Endpoint == "" {
+		l.Endpoint = API_ENDPOINT
+	}
+
+	if l.CallbackAddr == "" {
+		l.CallbackAddr = "127.0.0.1:0"
+	}
+
+	if l.OpenURL == nil {
+		l.OpenURL = openBrowser
+	}
+
+	if l.Out == nil {
+		l.Out = os.Stderr
+	}
+
+	if l.HTTPClient == nil {
+		l.HTTPClient = http.DefaultClient
+	}
+}
+
+// oauthRequest sends a signed POST request to an OAuth1 endpoint and parse the form-encoded response.
+func (l *OAuth1Login) oauthRequest(ctx context.Context, path string, signer OAuth1Config, oauthParams url.Values) (url.Values, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.Endpoint+path, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	params := signer.protocolParameters()
+	for k, values := range oauthParams {
+		params[k] = values
+	}
+
+	req.Header.Set("User-Agent", userAgent())
+	req.Header.Set("Authorization", signer.authorizationHeader(req.Method, req.URL, req.URL.Query(), params))
+
+	res, err := l.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read response body")
+	}
+
+	if res.StatusCode >= 300 {
+		return nil, newAPIError(res, body)
+	}
+
+	values, err := url.ParseQuery(strings.TrimSpace(string(body)))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse response body")
+	}
+
+	return values, nil
+}
+
+// callbackHandler capture the verifier given by the API when redirecting the user.
+func (l *OAuth1Login) callbackHandler(requestToken string, verifiers chan<- string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		verifier := query.Get("oauth_verifier")
+
+		if query.Get("oauth_token") != requestToken || verifier == "" {
+			http.Error(w, "invalid OAuth1 callback", http.StatusBadRequest)
+
+			return
+		}
+
+		select {
+		case verifiers <- verifier:
+			fmt.Fprint(w, "Logged in to Clever Cloud, you can close this window.\n")
+		default:
+			http.Error(w, "OAuth1 callback already received", http.StatusConflict)
+		}
+	})
+
+	return mux
+}
+
+// openBrowser try to open the given URL with the user default browser.
+func openBrowser(u string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u) // #nosec G204
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u) // #nosec G204
+	default:
+		cmd = exec.Command("xdg-open", u) // #nosec G204
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	go func() { _ = cmd.Wait() }()
+
+	return nil
+}
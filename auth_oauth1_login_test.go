@@ -0,0 +1,80 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_OAuth1Login(t *testing.T) {
+	t.Parallel()
+
+	oauthParam := func(r *http.Request, name string) string {
+		m := regexp.MustCompile(name + `="([^"]*)"`).FindStringSubmatch(r.Header.Get("Authorization"))
+		if m == nil {
+			return ""
+		}
+
+		v, _ := url.QueryUnescape(m[1])
+
+		return v
+	}
+
+	var callback string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/oauth/request_token", func(w http.ResponseWriter, r *http.Request) {
+		callback = oauthParam(r, "oauth_callback")
+		_, _ = w.Write([]byte("oauth_token=request-token&oauth_token_secret=request-secret&oauth_callback_confirmed=true"))
+	})
+	mux.HandleFunc("/v2/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, callback+"?oauth_token="+r.URL.Query().Get("oauth_token")+"&oauth_verifier=verifier", http.StatusFound)
+	})
+	mux.HandleFunc("/v2/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		if oauthParam(r, "oauth_token") != "request-token" || oauthParam(r, "oauth_verifier") != "verifier" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("oauth_token=access-token&oauth_token_secret=access-secret"))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	login := &client.OAuth1Login{
+		Endpoint: srv.URL,
+		Out:      io.Discard,
+		// act as the user authorizing the application in a browser
+		OpenURL: func(authorizeURL string) error {
+			go func() {
+				res, err := http.Get(authorizeURL) // #nosec G107
+				if err == nil {
+					res.Body.Close()
+				}
+			}()
+
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conf, err := login.Login(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if conf.AccessToken != "access-token" || conf.AccessSecret != "access-secret" || conf.ConsumerKey != client.OAUTH_CONSUMER_KEY {
+		t.Errorf("unexpected credentials: %+v", conf)
+	}
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- RFC 5849 examples are signed with HMAC-SHA1
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// https://www.rfc-editor.org/rfc/rfc5849#section-3.4.1.1
+func Test_signatureBaseString_RFC5849(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		"http://example.com/request?b5=%3D%253D&a3=a&c%40=&a2=r%20b",
+		strings.NewReader("c2&a3=2+q"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	params, err := requestParameters(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oauthParams := url.Values{
+		"oauth_consumer_key":     {"9djdj82h48djs9d2"},
+		"oauth_token":            {"kkk9d7dh3k39sjv7"},
+		"oauth_signature_method": {"HMAC-SHA1"},
+		"oauth_timestamp":        {"137131201"},
+		"oauth_nonce":            {"7d8f3e4a"},
+		"realm":                  {"Example"},
+	}
+	for k, v := range oauthParams {
+		params[k] = v
+	}
+
+	want := "POST&http%3A%2F%2Fexample.com%2Frequest&a2%3Dr%2520b%26a3%3D2%2520q" +
+		"%26a3%3Da%26b5%3D%253D%25253D%26c%2540%3D%26c2%3D%26oauth_consumer_" +
+		"key%3D9djdj82h48djs9d2%26oauth_nonce%3D7d8f3e4a%26oauth_signature_m" +
+		"ethod%3DHMAC-SHA1%26oauth_timestamp%3D137131201%26oauth_token%3Dkkk" +
+		"9d7dh3k39sjv7"
+
+	if got := signatureBaseString(req.Method, req.URL, params); got != want {
+		t.Errorf("signatureBaseString()\n got: %s\nwant: %s", got, want)
+	}
+
+	// body must still be readable after signature
+	body := make([]byte, 32)
+	if n, _ := req.Body.Read(body); string(body[:n]) != "c2&a3=2+q" {
+		t.Errorf("expect request body to be preserved, got %q", string(body[:n]))
+	}
+}
+
+// https://www.rfc-editor.org/rfc/rfc5849#section-1.2
+func Test_signature_RFC5849(t *testing.T) {
+	t.Parallel()
+
+	u, _ := url.Parse("http://photos.example.net/photos?file=vacation.jpg&size=original")
+	params := u.Query()
+	params.Set("oauth_consumer_key", "dpf43f3p2l4k3l03")
+	params.Set("oauth_token", "nnch734d00sl2jdk")
+	params.Set("oauth_signature_method", "HMAC-SHA1")
+	params.Set("oauth_timestamp", "137131202")
+	params.Set("oauth_nonce", "chapoH")
+
+	base := signatureBaseString(http.MethodGet, u, params)
+	key := percentEncode("kd94hf93k423kf44") + "&" + percentEncode("pfkkdhi9sl3r4s00")
+
+	hash := hmac.New(sha1.New, []byte(key))
+	hash.Write([]byte(base))
+
+	if got := base64.StdEncoding.EncodeToString(hash.Sum(nil)); got != "MdpQcU8iPSUjWoN/UDMsK2sui9I=" {
+		t.Errorf("expect RFC signature, got %s for base string %s", got, base)
+	}
+}
+
+// https://www.rfc-editor.org/rfc/rfc5849#section-3.4.1.2
+func Test_baseStringURI(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"HTTP://EXAMPLE.COM:80/r%20v/X?id=123":    "http://example.com/r%20v/X",
+		"https://www.example.net:8080/?q=1":       "https://www.example.net:8080/",
+		"https://api.clever-cloud.com:443":        "https://api.clever-cloud.com/",
+		"http://Example.com:443/path#fragment":    "http://example.com:443/path",
+		"https://api.clever-cloud.com/v2/self?a=": "https://api.clever-cloud.com/v2/self",
+	}
+
+	for raw, want := range tests {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := baseStringURI(u); got != want {
+			t.Errorf("baseStringURI(%s) = %s, want %s", raw, got, want)
+		}
+	}
+}
+
+// https://www.rfc-editor.org/rfc/rfc5849#section-3.6
+func Test_percentEncode(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"abcABC123-._~": "abcABC123-._~",
+		"r b":           "r%20b",
+		"=%3D":          "%3D%253D",
+		"c@":            "c%40",
+		"+/é":           "%2B%2F%C3%A9",
+	}
+
+	for raw, want := range tests {
+		if got := percentEncode(raw); got != want {
+			t.Errorf("percentEncode(%q) = %s, want %s", raw, got, want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.clever-cloud.dev/client"
+)
+
+// refreshingAuth simulate an expiring token.
+type refreshingAuth struct {
+	mu    sync.Mutex
+	token string
+}
+
+func (a *refreshingAuth) Sign(req *http.Request) {
+	_ = a.SignRequest(req.Context(), req)
+}
+
+func (a *refreshingAuth) SignRequest(ctx context.Context, req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	req.Header.Set("Authorization", "Bearer "+a.token)
+
+	return nil
+}
+
+func (a *refreshingAuth) Refresh(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.token = "fresh"
+
+	return nil
+}
+
+func Test_client_RefreshOnUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	auth := &refreshingAuth{token: "expired"}
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithCredentialProvider(client.StaticProvider(auth)),
+	)
+
+	if res := client.Get[client.Nothing](context.Background(), c, "/"); res.HasError() {
+		t.Errorf("expect request to succeed after refresh, got: %v", res.Error())
+	}
+}
+
+func Test_client_SignError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request must not be sent without credentials")
+	}))
+	defer srv.Close()
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithCredentialProvider(client.StaticProvider(&client.OAuth1Config{})),
+		client.WithRetryPolicy(client.DefaultRetryPolicy()),
+	)
+
+	if res := client.Get[client.Nothing](context.Background(), c, "/"); !res.HasError() {
+		t.Errorf("expect an error for missing OAuth1 credentials")
+	}
+}
+
+// countUnauthorized counts requests, it accepts only the given bearer token.
+func countUnauthorized(t *testing.T, token string) (*httptest.Server, func() int) {
+	t.Helper()
+
+	var mu sync.Mutex
+
+	requests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests++
+
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return requests
+	}
+}
+
+func Test_client_UnauthorizedStaticProvider(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := countUnauthorized(t, "valid")
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithCredentialProvider(client.StaticProvider(&client.BearerConfig{Token: "revoked"})),
+	)
+
+	if res := client.Get[client.Nothing](context.Background(), c, "/"); !client.IsUnauthorized(res.Error()) {
+		t.Errorf("expect a 401 error, got %v", res.Error())
+	}
+
+	if requests() != 1 {
+		t.Errorf("expect a single request, static credentials cannot be refreshed, got %d", requests())
+	}
+}
+
+func Test_client_UnauthorizedFuncProvider(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := countUnauthorized(t, "valid")
+
+	var mu sync.Mutex
+
+	tokens := []string{"expired", "valid"}
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithCredentialProvider(client.FuncProvider("vault", func(ctx context.Context) (client.Authenticator, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			token := tokens[0]
+			if len(tokens) > 1 {
+				tokens = tokens[1:]
+			}
+
+			return &client.BearerConfig{Token: token}, nil
+		})),
+	)
+
+	if res := client.Get[client.Nothing](context.Background(), c, "/"); res.HasError() {
+		t.Errorf("expect request to succeed with new credentials, got %v", res.Error())
+	}
+
+	if requests() != 2 {
+		t.Errorf("expect a retry with new credentials, got %d requests", requests())
+	}
+}
+
+func Test_client_UnauthorizedSameCredentials(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := countUnauthorized(t, "valid")
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithCredentialProvider(client.FuncProvider("vault", func(ctx context.Context) (client.Authenticator, error) {
+			return &client.BearerConfig{Token: "revoked"}, nil
+		})),
+	)
+
+	if res := client.Get[client.Nothing](context.Background(), c, "/"); !client.IsUnauthorized(res.Error()) {
+		t.Errorf("expect a 401 error, got %v", res.Error())
+	}
+
+	if requests() != 1 {
+		t.Errorf("expect a single request when credentials did not change, got %d", requests())
+	}
+}
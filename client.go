@@ -7,20 +7,34 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	otel "go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace"
 )
 
 // Client is a wrapped HTTP client used to contact CleverCloud API.
 type Client struct {
-	httpClient    *http.Client
-	authenticator Authenticator
-	endpoint      string
-	log           logrus.FieldLogger
+	httpClient         *http.Client
+	authMu             sync.RWMutex
+	authenticator      Authenticator
+	credentialProvider CredentialProvider
+	credentialSource   string
+	endpoint           string
+	bridgeEndpoint     string
+	log                logrus.FieldLogger
+	ownLogger          bool // log is the default logger, not one given with WithLogger()
+	retryPolicy        *RetryPolicy
+	rateLimiter        *rateLimiter
+	concurrency        semaphore
+	middlewares        []Middleware
+	doer               Doer
+	requestTimeout     time.Duration
+	configErr          error
 }
 
 // New instantiate a new CleverCloud client with options.
@@ -30,16 +44,20 @@ func New(options ...func(*Client)) *Client {
 	discardLogger.Level = logrus.PanicLevel
 
 	c := &Client{
-		httpClient:    http.DefaultClient,
-		authenticator: nil,
-		endpoint:      API_ENDPOINT,
-		log:           discardLogger,
+		httpClient:     http.DefaultClient,
+		authenticator:  nil,
+		endpoint:       API_ENDPOINT,
+		bridgeEndpoint: BRIDGE_API_ENDPOINT,
+		log:            discardLogger,
+		ownLogger:      true,
 	}
 
 	for _, option := range options {
 		option(c)
 	}
 
+	c.doer = c.chain()
+
 	return c
 }
 
@@ -52,18 +70,37 @@ func userAgent() string {
 	)
 }
 
-func request[T any](ctx context.Context, c *Client, method string, path string, payload interface{}) Response[T] {
+func request[T any](ctx context.Context, c *Client, method string, path string, payload interface{}, opts []RequestOption) Response[T] {
 	if c == nil {
 		return fromError[T](errors.New("expect non nil client"))
 	}
 
-	url := fmt.Sprintf("%s%s", c.endpoint, path)
+	if c.configErr != nil {
+		return fromError[T](c.configErr)
+	}
+
+	o := newRequestOptions(opts)
+	if o.timeout == 0 {
+		o.timeout = c.requestTimeout
+	}
+
+	url, err := o.url(c.baseURL(o), path)
+	if err != nil {
+		return fromError[T](errors.Wrap(err, "failed to build CleverCloud API request"))
+	}
+
 	ctx = mustContext(ctx)
 
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+
+		defer cancel()
+	}
+
 	body := []byte{}
 
 	if payload != nil {
-		var err error
 		body, err = json.Marshal(payload)
 
 		if err != nil {
@@ -71,87 +108,199 @@ func request[T any](ctx context.Context, c *Client, method string, path string,
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
-	if err != nil {
-		return fromError[T](errors.Wrap(err, "failed to build CleverCloud API request"))
-	}
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
 
-	otel.Inject(ctx, req)
-	req.Header.Set("User-Agent", userAgent())
+		if len(body) != 0 {
+			req.Header.Set("Content-Type", "application/json")
+		}
 
-	if len(body) != 0 {
-		req.Header.Set("Content-Type", "application/json")
-	}
+		o.apply(req)
 
-	if c.authenticator != nil {
-		c.authenticator.Sign(req)
+		return req, nil
 	}
 
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(ctx, newRequest)
 	if err != nil {
-		c.log.Warnf("RESPONSE:\t%s\t%s\t->\t%+v", req.Method, req.URL.String(), err.Error())
-
 		return fromError[T](errors.Wrap(err, "failed to build CleverCloud API request"))
 	}
 
-	c.log.Infof("RESPONSE:\t%s\t%s\t->\t%s", req.Method, req.URL.String(), res.Status)
 	defer res.Body.Close()
 
 	return fromHTTPResponse[T](res)
 }
 
+// Err returns the configuration error which makes all requests fail, if any.
+func (c *Client) Err() error {
+	return c.configErr
+}
+
+// Logger returns the client logger, for services to log through it.
+func (c *Client) Logger() logrus.FieldLogger {
+	return c.log
+}
+
+// baseURL returns the endpoint a request must be sent to.
+func (c *Client) baseURL(o *requestOptions) string {
+	if o.bridge {
+		return c.bridgeEndpoint
+	}
+
+	return c.endpoint
+}
+
 func (c *Client) Authenticator() Authenticator {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+
 	return c.authenticator
 }
 
+func (c *Client) setAuthenticator(auth Authenticator) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	c.authenticator = auth
+}
+
+// refreshCredentials renew credentials after a 401 response, using the authenticator
+// if it is a Refresher, else the credential provider it comes from.
+func (c *Client) refreshCredentials(ctx context.Context) error {
+	if refresher, ok := c.Authenticator().(Refresher); ok {
+		return refresher.Refresh(ctx)
+	}
+
+	if c.credentialProvider != nil {
+		if !canRefresh(c.credentialProvider) {
+			return errors.Errorf("credentials from %s cannot be refreshed", c.credentialProvider.Name())
+		}
+
+		auth, err := c.credentialProvider.Retrieve(ctx)
+		if err != nil {
+			return err
+		}
+
+		// the same credentials would be rejected again
+		if reflect.DeepEqual(auth, c.Authenticator()) {
+			return errors.Errorf("%s returned the same credentials", c.credentialProvider.Name())
+		}
+
+		c.setAuthenticator(auth)
+
+		return nil
+	}
+
+	return errors.New("credentials cannot be refreshed")
+}
+
 // Perform a GET request.
-func Get[T any](ctx context.Context, c *Client, path string) Response[T] {
-	return request[T](ctx, c, http.MethodGet, path, nil)
+func Get[T any](ctx context.Context, c *Client, path string, opts ...RequestOption) Response[T] {
+	return request[T](ctx, c, http.MethodGet, path, nil, opts)
 }
 
 // Perform a POST request.
-func Post[T any](ctx context.Context, c *Client, path string, payload interface{}) Response[T] {
-	return request[T](ctx, c, http.MethodPost, path, payload)
+func Post[T any](ctx context.Context, c *Client, path string, payload interface{}, opts ...RequestOption) Response[T] {
+	return request[T](ctx, c, http.MethodPost, path, payload, opts)
 }
 
 // Perform a PUT request.
-func Put[T any](ctx context.Context, c *Client, path string, payload interface{}) Response[T] {
-	return request[T](ctx, c, http.MethodPut, path, payload)
+func Put[T any](ctx context.Context, c *Client, path string, payload interface{}, opts ...RequestOption) Response[T] {
+	return request[T](ctx, c, http.MethodPut, path, payload, opts)
 }
 
 // Perform a DELETE request.
-func Delete[T any](ctx context.Context, c *Client, path string) Response[T] {
-	return request[T](ctx, c, http.MethodDelete, path, nil)
+func Delete[T any](ctx context.Context, c *Client, path string, opts ...RequestOption) Response[T] {
+	return request[T](ctx, c, http.MethodDelete, path, nil, opts)
 }
 
 // Perform a PATCH request.
-func Patch[T any](ctx context.Context, c *Client, path string, payload interface{}) Response[T] {
-	return request[T](ctx, c, http.MethodPatch, path, payload)
+func Patch[T any](ctx context.Context, c *Client, path string, payload interface{}, opts ...RequestOption) Response[T] {
+	return request[T](ctx, c, http.MethodPatch, path, payload, opts)
 }
 
 // Perform an SSE request.
-func Stream[T any](ctx context.Context, c *Client, path string) StreamResponse[T] {
-	url := fmt.Sprintf("%s%s", c.endpoint, path)
-	ctx = mustContext(ctx)
+// With WithReconnect(), the stream is reopened when its connection ends, until Close() or context cancellation.
+// A 204 No Content answer ends the stream without error, the server asks not to reconnect.
+func Stream[T any](ctx context.Context, c *Client, path string, opts ...RequestOption) StreamResponse[T] {
+	if c == nil {
+		return fromErrorStream[T](errors.New("expect non nil client"))
+	}
+
+	if c.configErr != nil {
+		return fromErrorStream[T](c.configErr)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	o := newRequestOptions(opts)
+
+	url, err := o.url(c.baseURL(o), path)
 	if err != nil {
 		return fromErrorStream[T](errors.Wrap(err, "failed to build CleverCloud API request"))
 	}
 
-	otel.Inject(ctx, req)
-	req.Header.Set("User-Agent", userAgent())
+	// Close() cancels the stream context to abort the request
+	var cancel context.CancelFunc
+
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(mustContext(ctx), o.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(mustContext(ctx))
+	}
+
+	// each connection goes through the whole chain, so that it is signed again
+	connect := func(lastEventID string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Accept", "text/event-stream")
+		o.apply(req)
+
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
 
-	if c.authenticator != nil {
-		c.authenticator.Sign(req)
+		return c.doer.Do(req)
 	}
 
-	res, err := c.httpClient.Do(req)
+	res, err := connect("")
 	if err != nil {
-		res.Body.Close()
+		if res != nil {
+			res.Body.Close()
+		}
+
+		cancel()
 
 		return fromErrorStream[T](errors.Wrap(err, "failed to build CleverCloud API request"))
 	}
 
-	return fromHTTPStream[T](res)
+	var reconnect *streamReconnect
+	if o.reconnect {
+		policy := DefaultRetryPolicy()
+		if c.retryPolicy != nil {
+			policy = c.retryPolicy.withDefaults()
+		}
+
+		reconnect = &streamReconnect{
+			connect:     connect,
+			backoff:     policy.backoff,
+			maxAttempts: policy.MaxAttempts,
+			notify: func(ev ReconnectEvent) {
+				if ev.Err != nil {
+					c.log.Warnf("RECONNECT:\t%s\t->\tattempt %d in %s: %s", url, ev.Attempt, ev.Delay, ev.Err.Error())
+				} else {
+					c.log.Infof("RECONNECT:\t%s\t->\tattempt %d in %s", url, ev.Attempt, ev.Delay)
+				}
+
+				if o.onReconnect != nil {
+					o.onReconnect(ev)
+				}
+			},
+		}
+	}
+
+	return fromHTTPStream[T](ctx, res, cancel, reconnect, o.decoders)
 }
@@ -4,37 +4,59 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 
 	"github.com/adrg/xdg"
+	"github.com/pkg/errors"
 )
 
 // Best effort to grep credentials from environment
 // Used internally or for extracting credentials.
 func (c *Client) GuessOauth1Config() *OAuth1Config {
-	if conf := c.guessOauth1ConfigFromEnv(); conf != nil {
+	conf, err := oauth1ConfigFromEnv()
+	if err == nil {
 		c.log.Info("Using Oauth1 user env vars")
 
 		return conf
 	}
 
-	if conf := c.guessOauth1ConfigFromConfigFile(); conf != nil {
+	c.logCredentialsError(err)
+
+	conf, err = oauth1ConfigFromConfigFile()
+	if err == nil {
 		c.log.Info("Using Oauth1 user config file")
 
 		return conf
 	}
 
+	c.logCredentialsError(err)
+
 	return nil
 }
 
-// guessOauth1ConfigFromEnv try to load OAuth1 credentials from user environment variables.
-func (c *Client) guessOauth1ConfigFromEnv() *OAuth1Config {
+func (c *Client) logCredentialsError(err error) {
+	var insecure *insecureConfigFileError
+
+	if errors.Is(err, ErrNoCredentials) {
+		c.log.Debug(err.Error())
+	} else if errors.As(err, &insecure) {
+		c.log.Warnf(
+			"ignoring config file '%s' readable by other users (mode %#o), fix it with 'chmod 600 %s' or set %s=1 to read it anyway",
+			insecure.path, insecure.perm, insecure.path, INSECURE_CONFIG_ENV,
+		)
+	} else {
+		c.log.WithError(err).Warn("cannot load credentials")
+	}
+}
+
+// oauth1ConfigFromEnv try to load OAuth1 credentials from user environment variables.
+func oauth1ConfigFromEnv() (*OAuth1Config, error) {
 	secret := os.Getenv("CC_OAUTH_SECRET")
 	token := os.Getenv("CC_OAUTH_TOKEN")
 
 	if secret == "" || token == "" {
-		c.log.Debug("Oauth1 user env vars are not set")
-
-		return nil
+		return nil, errors.Wrap(ErrNoCredentials, "Oauth1 user env vars are not set")
 	}
 
 	return &OAuth1Config{
@@ -42,12 +64,12 @@ func (c *Client) guessOauth1ConfigFromEnv() *OAuth1Config {
 		AccessToken:    token,
 		ConsumerKey:    os.Getenv("CC_CONSUMER_KEY"),
 		ConsumerSecret: os.Getenv("CC_CONSUMER_SECRET"),
-	}
+	}, nil
 }
 
-// guessOauth1ConfigFromConfigFile try to load OAuth1 credentials from user files.
-func (c *Client) guessOauth1ConfigFromConfigFile() *OAuth1Config {
-	path := fmt.Sprintf("%s/%s", CONFIG_DIR, CONFIG_FILE_NAME)
+// configFilePath returns the path of an existing configuration file, or "".
+func configFilePath(name string) string {
+	path := fmt.Sprintf("%s/%s", CONFIG_DIR, name)
 	configFilePath, _ := xdg.SearchConfigFile(path)
 
 	// while clever-tools does not use right OSX XDG paths, force them
@@ -61,35 +83,54 @@ func (c *Client) guessOauth1ConfigFromConfigFile() *OAuth1Config {
 		}
 	}
 
-	if configFilePath == "" {
-		c.log.Debug("not user define configuration file")
+	return configFilePath
+}
 
-		return nil
+// writableConfigFilePath returns where a configuration file must be written:
+// the existing one if any, else the location clever-tools would use.
+func writableConfigFilePath(name string) (string, error) {
+	if existing := configFilePath(name); existing != "" {
+		return existing, nil
 	}
 
-	c.log.Debugf("Trying to get config from '%s'", configFilePath)
+	path := fmt.Sprintf("%s/%s", CONFIG_DIR, name)
 
-	content, err := os.ReadFile(configFilePath)
-	if err != nil {
-		c.log.WithError(err).Warn("cannot read user config file")
+	// same OSX fallback as configFilePath()
+	if runtime.GOOS == "darwin" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", errors.Wrap(err, "cannot find user home directory")
+		}
 
-		return nil
+		return fmt.Sprintf("%s/.config/%s", home, path), nil
+	}
+
+	return filepath.Join(xdg.ConfigHome, path), nil
+}
+
+// oauth1ConfigFromConfigFile try to load OAuth1 credentials from user files.
+func oauth1ConfigFromConfigFile() (*OAuth1Config, error) {
+	configFilePath := configFilePath(CONFIG_FILE_NAME)
+
+	if configFilePath == "" {
+		return nil, errors.Wrap(ErrNoCredentials, "not user define configuration file")
+	}
+
+	content, err := readConfigFile(configFilePath)
+	if err != nil {
+		return nil, err
 	}
 
 	var conf OAuth1Config
 	if err := json.Unmarshal(content, &conf); err != nil {
-		c.log.WithError(err).Warn("cannot parse user config file")
-
-		return nil
+		return nil, errors.Wrapf(err, "cannot parse user config file '%s'", configFilePath)
 	}
 
 	if conf.AccessSecret == "" || conf.AccessToken == "" {
-		c.log.Debug("Oauth1 user config file vars are not set")
-
-		return nil
+		return nil, errors.Wrap(ErrNoCredentials, "Oauth1 user config file vars are not set")
 	}
 
-	return &conf
+	return &conf, nil
 }
 
 func (c *Client) guessBearerConfigFromEnv() *BearerConfig {
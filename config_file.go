@@ -0,0 +1,164 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// readConfigFile read a configuration file holding credentials,
+// refusing files readable by group or others.
+func readConfigFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read config file '%s'", path)
+	}
+
+	// permissions bits are meaningless on windows
+	if perm := info.Mode().Perm(); runtime.GOOS != "windows" && perm&0o077 != 0 && os.Getenv(INSECURE_CONFIG_ENV) == "" {
+		return nil, &insecureConfigFileError{path: path, perm: perm}
+	}
+
+	return readReplacedConfigFile(path)
+}
+
+// readReplacedConfigFile read a configuration file about to be rewritten,
+// its permissions are not checked as the rewrite makes it only readable by its owner.
+func readReplacedConfigFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read config file '%s'", path)
+	}
+
+	return content, nil
+}
+
+// insecureConfigFileError is returned for a config file readable by group or others.
+type insecureConfigFileError struct {
+	path string
+	perm os.FileMode
+}
+
+func (e *insecureConfigFileError) Error() string {
+	return fmt.Sprintf(
+		"config file '%s' is accessible by other users (mode %#o), run 'chmod 600 %s' or set %s=1",
+		e.path, e.perm, e.path, INSECURE_CONFIG_ENV,
+	)
+}
+
+// writeConfigFile atomically replace a configuration file, only readable by its owner.
+func writeConfigFile(path string, content []byte) error {
+	dir := filepath.Dir(path)
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return errors.Wrap(err, "cannot create config directory")
+	}
+
+	tmp, err := os.CreateTemp(dir, fmt.Sprintf(".%s.*", filepath.Base(path)))
+	if err != nil {
+		return errors.Wrap(err, "cannot create temporary config file")
+	}
+
+	// no-op once renamed
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+
+		return errors.Wrap(err, "cannot restrict config file permissions")
+	}
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+
+		return errors.Wrap(err, "cannot write config file")
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return errors.Wrap(err, "cannot write config file")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "cannot write config file")
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrapf(err, "cannot write config file '%s'", path)
+	}
+
+	return nil
+}
+
+// SaveOAuth1Config save OAuth1 user credentials in clever-tools configuration file,
+// returns the file path. Other keys of an existing file are kept.
+func SaveOAuth1Config(conf *OAuth1Config) (string, error) {
+	path, err := writableConfigFilePath(CONFIG_FILE_NAME)
+	if err != nil {
+		return "", err
+	}
+
+	content := map[string]interface{}{}
+
+	if _, err := os.Stat(path); err == nil {
+		existing, err := readReplacedConfigFile(path)
+		if err != nil {
+			return "", err
+		}
+
+		if err := json.Unmarshal(existing, &content); err != nil {
+			return "", errors.Wrapf(err, "cannot parse existing config file '%s'", path)
+		}
+	}
+
+	content["token"] = conf.AccessToken
+	content["secret"] = conf.AccessSecret
+
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot serialize config file")
+	}
+
+	return path, writeConfigFile(path, raw)
+}
+
+// SaveProfile add or replace a profile in the profiles file, returns the file path.
+func SaveProfile(name string, profile Profile) (string, error) {
+	if name == "" {
+		return "", errors.New("expect a profile name")
+	}
+
+	path, err := writableConfigFilePath(PROFILES_FILE_NAME)
+	if err != nil {
+		return "", err
+	}
+
+	profiles, err := loadProfilesFile(readReplacedConfigFile)
+	if err != nil {
+		return "", err
+	}
+
+	profiles.Profiles[name] = profile
+
+	raw, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "cannot serialize profiles file")
+	}
+
+	return path, writeConfigFile(path, raw)
+}
+
+// SaveBearerConfig save a bearer token as a profile of the profiles file, returns the file path.
+// Bearer tokens are not supported by clever-tools configuration file.
+func SaveBearerConfig(profileName string, conf *BearerConfig) (string, error) {
+	return SaveProfile(profileName, Profile{
+		Kind:     "bearer",
+		Endpoint: BRIDGE_API_ENDPOINT,
+		Token:    conf.Token,
+	})
+}
@@ -0,0 +1,111 @@
+package client_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.clever-cloud.dev/client"
+)
+
+func Test_SaveCredentials(t *testing.T) {
+	dir := withConfigHome(t, map[string]string{
+		client.CONFIG_FILE_NAME: `{"token":"old","secret":"old","expirationDate":"2030-01-01"}`,
+	})
+
+	path, err := client.SaveOAuth1Config(&client.OAuth1Config{AccessToken: "token", AccessSecret: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, _ := os.ReadFile(path)
+	content := map[string]string{}
+	_ = json.Unmarshal(raw, &content)
+
+	if content["token"] != "token" || content["expirationDate"] != "2030-01-01" {
+		t.Errorf("expect credentials to be replaced and other keys kept, got %s", string(raw))
+	}
+
+	path, err = client.SaveBearerConfig("ci", &client.BearerConfig{Token: "ci-token"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if path != filepath.Join(dir, client.PROFILES_FILE_NAME) {
+		t.Errorf("unexpected profiles file path %s", path)
+	}
+
+	for _, p := range []string{path, filepath.Join(dir, client.CONFIG_FILE_NAME)} {
+		if info, err := os.Stat(p); err != nil || info.Mode().Perm() != 0o600 {
+			t.Errorf("expect %s to be only readable by its owner, got %v (%v)", p, info.Mode(), err)
+		}
+	}
+
+	c := client.New(client.WithProfile("ci"))
+	if auth, ok := c.Authenticator().(*client.BearerConfig); !ok || auth.Token != "ci-token" {
+		t.Errorf("expect saved profile to be loaded, got %+v", c.Authenticator())
+	}
+}
+
+func Test_SaveCredentials_overInsecureFiles(t *testing.T) {
+	dir := withConfigHome(t, map[string]string{
+		client.CONFIG_FILE_NAME:   `{"token":"old","secret":"old"}`,
+		client.PROFILES_FILE_NAME: `{"profiles":{"ci":{"kind":"bearer","token":"old"}}}`,
+	})
+
+	for _, name := range []string{client.CONFIG_FILE_NAME, client.PROFILES_FILE_NAME} {
+		if err := os.Chmod(filepath.Join(dir, name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := client.SaveOAuth1Config(&client.OAuth1Config{AccessToken: "token", AccessSecret: "secret"}); err != nil {
+		t.Errorf("expect a world-readable config file to be replaced, got %v", err)
+	}
+
+	if _, err := client.SaveBearerConfig("ci", &client.BearerConfig{Token: "ci-token"}); err != nil {
+		t.Errorf("expect a world-readable profiles file to be replaced, got %v", err)
+	}
+
+	for _, name := range []string{client.CONFIG_FILE_NAME, client.PROFILES_FILE_NAME} {
+		if info, err := os.Stat(filepath.Join(dir, name)); err != nil || info.Mode().Perm() != 0o600 {
+			t.Errorf("expect %s to be only readable by its owner once saved, got %v (%v)", name, info.Mode(), err)
+		}
+	}
+}
+
+func Test_readInsecureConfigFile(t *testing.T) {
+	dir := withConfigHome(t, map[string]string{
+		client.CONFIG_FILE_NAME: `{"token":"token","secret":"secret"}`,
+	})
+
+	path := filepath.Join(dir, client.CONFIG_FILE_NAME)
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+
+	log := logrus.New()
+	log.SetOutput(&logs)
+
+	if c := client.New(client.WithLogger(log), client.WithAutoOauthConfig()); c.Authenticator() != nil {
+		t.Errorf("expect a world-readable config file to be refused")
+	}
+
+	if out := logs.String(); !strings.Contains(out, "level=warning") ||
+		!strings.Contains(out, "chmod 600 "+path) ||
+		!strings.Contains(out, client.INSECURE_CONFIG_ENV) {
+		t.Errorf("expect a warning with the fix, got: %s", out)
+	}
+
+	t.Setenv(client.INSECURE_CONFIG_ENV, "1")
+
+	if c := client.New(client.WithAutoOauthConfig()); c.Authenticator() == nil {
+		t.Errorf("expect a world-readable config file to be read with %s", client.INSECURE_CONFIG_ENV)
+	}
+}
@@ -8,5 +8,12 @@ const OAUTH_CONSUMER_SECRET = "J9k249yV0UqIzsGJWAVD4p1HLj8NyE" // #nosec G101
 // For XDG.
 const CONFIG_DIR = "clever-cloud"
 const CONFIG_FILE_NAME = "clever-tools.json"
+const PROFILES_FILE_NAME = "profiles.json"
+
+// Profile used when none is selected, legacy clever-tools configuration file is read as this one.
+const DEFAULT_PROFILE = "default"
+
+// Set this env var to read configuration files other users can read.
+const INSECURE_CONFIG_ENV = "CC_INSECURE_CONFIG_FILE"
 
 const CLIENT_VERSION = "v0.0.1"
@@ -1,6 +1,9 @@
 package client
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 func mustContext(ctx context.Context) context.Context {
 	if ctx == nil {
@@ -9,3 +12,16 @@ func mustContext(ctx context.Context) context.Context {
 
 	return ctx
 }
+
+// sleep wait for the given duration, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,266 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoCredentials is returned by providers which did not find any credentials.
+var ErrNoCredentials = errors.New("no credentials found")
+
+// CredentialProvider find credentials to authenticate requests.
+type CredentialProvider interface {
+	// Describe where credentials come from, shown to users
+	Name() string
+	// Load credentials, returns an error wrapping ErrNoCredentials if there is none
+	Retrieve(ctx context.Context) (Authenticator, error)
+}
+
+// RefreshableProvider is implemented by providers which know if retrieving credentials again can give new ones.
+// On a 401 response, providers which cannot refresh are not asked again, the error is returned at once.
+// Providers which do not implement it are asked again, and the request is sent once more only if credentials changed.
+type RefreshableProvider interface {
+	CanRefresh() bool
+}
+
+// DefaultCredentialProvider look for OAuth1 credentials in environment, then in the selected
+// profile (falling back on clever-tools configuration file), then for a bearer token in environment.
+func DefaultCredentialProvider() *ChainProvider {
+	return NewChainProvider(
+		EnvOAuth1Provider(),
+		NewProfileProvider(""),
+		EnvBearerProvider(),
+	)
+}
+
+// ChainProvider returns credentials of the first provider which has some.
+type ChainProvider struct {
+	providers []CredentialProvider
+
+	mu     sync.Mutex
+	chosen CredentialProvider
+}
+
+// NewChainProvider try each provider in the given order.
+func NewChainProvider(providers ...CredentialProvider) *ChainProvider {
+	return &ChainProvider{providers: providers}
+}
+
+// Name returns the name of the provider credentials came from, once retrieved.
+func (p *ChainProvider) Name() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.chosen == nil {
+		names := make([]string, 0, len(p.providers))
+		for _, provider := range p.providers {
+			names = append(names, provider.Name())
+		}
+
+		return fmt.Sprintf("chain(%s)", strings.Join(names, ", "))
+	}
+
+	return p.chosen.Name()
+}
+
+// Chosen returns the provider credentials came from, nil if not retrieved yet.
+func (p *ChainProvider) Chosen() CredentialProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.chosen
+}
+
+// CanRefresh tells if the provider credentials came from can give new ones.
+func (p *ChainProvider) CanRefresh() bool {
+	chosen := p.Chosen()
+	if chosen == nil {
+		return true
+	}
+
+	return canRefresh(chosen)
+}
+
+func canRefresh(provider CredentialProvider) bool {
+	if rp, ok := provider.(RefreshableProvider); ok {
+		return rp.CanRefresh()
+	}
+
+	return true
+}
+
+// Retrieve returns credentials of the first provider which has some.
+// Errors of providers are reported if none of them succeed.
+func (p *ChainProvider) Retrieve(ctx context.Context) (Authenticator, error) {
+	failures := []string{}
+	broken := false
+
+	for _, provider := range p.providers {
+		auth, err := provider.Retrieve(ctx)
+		if err == nil {
+			p.mu.Lock()
+			p.chosen = provider
+			p.mu.Unlock()
+
+			return auth, nil
+		}
+
+		failures = append(failures, fmt.Sprintf("%s: %s", provider.Name(), err.Error()))
+		broken = broken || !errors.Is(err, ErrNoCredentials)
+	}
+
+	if broken {
+		// do not hide a misconfigured provider behind ErrNoCredentials
+		return nil, errors.Errorf("cannot load credentials: %s", strings.Join(failures, "; "))
+	}
+
+	return nil, errors.Wrap(ErrNoCredentials, strings.Join(failures, "; "))
+}
+
+// providerFunc is the implementation of simple providers.
+type providerFunc struct {
+	name     string
+	retrieve func(ctx context.Context) (Authenticator, error)
+	// credentials never change during the process life
+	static bool
+}
+
+func (p *providerFunc) Name() string {
+	return p.name
+}
+
+func (p *providerFunc) CanRefresh() bool {
+	return !p.static
+}
+
+func (p *providerFunc) Retrieve(ctx context.Context) (Authenticator, error) {
+	return p.retrieve(ctx)
+}
+
+// FuncProvider use a custom function to get credentials.
+func FuncProvider(name string, retrieve func(ctx context.Context) (Authenticator, error)) CredentialProvider {
+	return &providerFunc{name: name, retrieve: retrieve}
+}
+
+// StaticProvider always returns the given credentials.
+func StaticProvider(auth Authenticator) CredentialProvider {
+	return &providerFunc{name: "static", static: true, retrieve: func(ctx context.Context) (Authenticator, error) {
+		if auth == nil {
+			return nil, errors.Wrap(ErrNoCredentials, "no static credentials")
+		}
+
+		return auth, nil
+	}}
+}
+
+// EnvOAuth1Provider read OAuth1 credentials from CC_OAUTH_TOKEN and CC_OAUTH_SECRET,
+// and optionally CC_CONSUMER_KEY and CC_CONSUMER_SECRET.
+func EnvOAuth1Provider() CredentialProvider {
+	return &providerFunc{name: "environment (CC_OAUTH_TOKEN)", static: true, retrieve: func(ctx context.Context) (Authenticator, error) {
+		conf, err := oauth1ConfigFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		return conf.withDefaultConsumer(), nil
+	}}
+}
+
+// CleverToolsFileProvider read OAuth1 credentials from clever-tools configuration file.
+func CleverToolsFileProvider() CredentialProvider {
+	return FuncProvider("clever-tools configuration file", func(ctx context.Context) (Authenticator, error) {
+		conf, err := oauth1ConfigFromConfigFile()
+		if err != nil {
+			return nil, err
+		}
+
+		return conf.withDefaultConsumer(), nil
+	})
+}
+
+// EnvBearerProvider read a bearer token from CLEVER_API_TOKEN.
+func EnvBearerProvider() CredentialProvider {
+	return &providerFunc{name: "environment (CLEVER_API_TOKEN)", static: true, retrieve: func(ctx context.Context) (Authenticator, error) {
+		token := os.Getenv("CLEVER_API_TOKEN")
+		if token == "" {
+			return nil, errors.Wrap(ErrNoCredentials, "no CLEVER_API_TOKEN set in env")
+		}
+
+		return &BearerConfig{Token: token}, nil
+	}}
+}
+
+// ExecProvider run a credential helper command, which must write credentials on stdout,
+// in the same format as a Profile:
+//
+//	{"kind": "oauth1", "token": "...", "secret": "..."}
+//	{"kind": "bearer", "token": "..."}
+func ExecProvider(command string, args ...string) CredentialProvider {
+	return FuncProvider(fmt.Sprintf("exec(%s)", command), func(ctx context.Context) (Authenticator, error) {
+		var stdout, stderr bytes.Buffer
+
+		cmd := exec.CommandContext(ctx, command, args...) // #nosec G204
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			return nil, errors.Wrapf(err, "credential helper failed: %s", strings.TrimSpace(stderr.String()))
+		}
+
+		var profile Profile
+		if err := json.Unmarshal(stdout.Bytes(), &profile); err != nil {
+			return nil, errors.Wrap(err, "cannot parse credential helper output")
+		}
+
+		auth, err := profile.Authenticator()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid credential helper output")
+		}
+
+		return auth, nil
+	})
+}
+
+// useCredentialProvider set client authenticator from the given provider.
+func (c *Client) useCredentialProvider(ctx context.Context, provider CredentialProvider) error {
+	auth, err := provider.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.setAuthenticator(auth)
+	c.credentialProvider = provider
+	c.credentialSource = provider.Name()
+	c.log.Infof("Using credentials from %s", c.credentialSource)
+
+	source := provider
+	if chain, ok := provider.(*ChainProvider); ok {
+		source = chain.Chosen()
+	}
+
+	if ep, ok := source.(endpointProvider); ok && ep.Endpoint() != "" {
+		c.endpoint = ep.Endpoint()
+	} else if _, ok := auth.(*BearerConfig); ok && c.endpoint == API_ENDPOINT {
+		c.endpoint = c.bridgeEndpoint
+	}
+
+	return nil
+}
+
+// endpointProvider is implemented by providers whose credentials come with an endpoint.
+type endpointProvider interface {
+	Endpoint() string
+}
+
+// CredentialSource tells where client credentials come from, empty if not set by a CredentialProvider.
+func (c *Client) CredentialSource() string {
+	return c.credentialSource
+}
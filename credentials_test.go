@@ -0,0 +1,122 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_ChainProvider(t *testing.T) {
+	t.Parallel()
+
+	empty := client.FuncProvider("empty", func(ctx context.Context) (client.Authenticator, error) {
+		return nil, client.ErrNoCredentials
+	})
+
+	tests := []struct {
+		name       string
+		providers  []client.CredentialProvider
+		wantSource string
+		wantToken  string
+		wantErr    error
+	}{{
+		name:       "first provider with credentials wins",
+		providers:  []client.CredentialProvider{empty, client.StaticProvider(&client.BearerConfig{Token: "static"})},
+		wantSource: "static",
+		wantToken:  "static",
+	}, {
+		name: "exec helper",
+		providers: []client.CredentialProvider{
+			empty,
+			client.ExecProvider("sh", "-c", `echo '{"kind":"oauth1","token":"token","secret":"secret"}'`),
+		},
+		wantSource: "exec(sh)",
+		wantToken:  "token",
+	}, {
+		name:      "no credentials",
+		providers: []client.CredentialProvider{empty, client.StaticProvider(nil)},
+		wantErr:   client.ErrNoCredentials,
+	}}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chain := client.NewChainProvider(tt.providers...)
+
+			auth, err := chain.Retrieve(context.Background())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expect error %v, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			token := ""
+			switch a := auth.(type) {
+			case *client.BearerConfig:
+				token = a.Token
+			case *client.OAuth1Config:
+				token = a.AccessToken
+
+				if a.ConsumerKey != client.OAUTH_CONSUMER_KEY {
+					t.Errorf("expect default consumer key, got %s", a.ConsumerKey)
+				}
+			}
+
+			if token != tt.wantToken {
+				t.Errorf("expect token %s, got %s", tt.wantToken, token)
+			}
+
+			c := client.New(client.WithCredentialProvider(chain))
+			if c.CredentialSource() != tt.wantSource {
+				t.Errorf("expect credentials from %s, got %s", tt.wantSource, c.CredentialSource())
+			}
+		})
+	}
+}
+
+func Test_ChainProvider_brokenProvider(t *testing.T) {
+	t.Parallel()
+
+	chain := client.NewChainProvider(client.ExecProvider("sh", "-c", "exit 1"))
+
+	if _, err := chain.Retrieve(context.Background()); err == nil || errors.Is(err, client.ErrNoCredentials) {
+		t.Errorf("expect a failing helper to be reported, got %v", err)
+	}
+}
+
+func Test_WithAutoAuthConfig_noCredentials(t *testing.T) {
+	withConfigHome(t, nil)
+	t.Setenv("CC_OAUTH_SECRET", "")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("expect an unsigned request, got Authorization: %s", auth)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithAutoAuthConfig(),
+	)
+
+	if c.Authenticator() != nil || c.CredentialSource() != "" {
+		t.Errorf("expect no credentials, got %T from '%s'", c.Authenticator(), c.CredentialSource())
+	}
+
+	if res := client.Get[client.Nothing](context.Background(), c, "/"); res.HasError() {
+		t.Errorf("expect the request to be sent, got %v", res.Error())
+	}
+}
@@ -0,0 +1,163 @@
+// Package deployments follow deployments of an application.
+// Owner IDs are resolved by client.OwnerPath().
+package deployments
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.clever-cloud.dev/client"
+)
+
+func deploymentsPath(ownerID, applicationID string) string {
+	return fmt.Sprintf("%s/applications/%s/deployments", client.OwnerPath(ownerID), url.PathEscape(applicationID))
+}
+
+func deploymentPath(ownerID, applicationID, deploymentID string) string {
+	return fmt.Sprintf("%s/%s", deploymentsPath(ownerID, applicationID), url.PathEscape(deploymentID))
+}
+
+// List deployments of an application, most recent first.
+// limit is ignored if not positive.
+func List(ctx context.Context, c *client.Client, ownerID, applicationID string, limit int) client.Response[[]Deployment] {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	return client.Get[[]Deployment](ctx, c, deploymentsPath(ownerID, applicationID), client.WithQuery(query))
+}
+
+// Get a deployment, by UUID.
+func Get(ctx context.Context, c *client.Client, ownerID, applicationID, deploymentID string) client.Response[Deployment] {
+	return client.Get[Deployment](ctx, c, deploymentPath(ownerID, applicationID, deploymentID))
+}
+
+// Cancel a running deployment.
+func Cancel(ctx context.Context, c *client.Client, ownerID, applicationID, deploymentID string) client.Response[client.Nothing] {
+	return client.Delete[client.Nothing](ctx, c, deploymentPath(ownerID, applicationID, deploymentID)+"/instances")
+}
+
+// WaitForDeployment blocks until the deployment reaches a terminal state, and returns it.
+// A failed or cancelled deployment is not an error, check its State.
+func WaitForDeployment(ctx context.Context, c *client.Client, ownerID, applicationID, deploymentID string, opts WaitOptions) (*Deployment, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 2 * time.Second
+	}
+
+	if opts.MaxPollInterval == 0 {
+		opts.MaxPollInterval = 15 * time.Second
+	}
+
+	if opts.MaxPollInterval < opts.PollInterval {
+		opts.MaxPollInterval = opts.PollInterval
+	}
+
+	if opts.NotFoundGracePeriod <= 0 {
+		opts.NotFoundGracePeriod = 30 * time.Second
+	}
+
+	if opts.StreamPath != "" {
+		deployment, err := follow(ctx, c, deploymentID, opts)
+		if !errors.Is(err, errNotFollowed) {
+			return deployment, err
+		}
+	}
+
+	return poll(ctx, c, ownerID, applicationID, deploymentID, opts)
+}
+
+// errNotFollowed is returned when the stream fails or ends before the deployment does, polling takes over.
+var errNotFollowed = errors.New("deployment not followed until its end")
+
+// follow read deployment updates from the stream until the deployment is done.
+func follow(ctx context.Context, c *client.Client, deploymentID string, opts WaitOptions) (*Deployment, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream := client.Stream[Deployment](ctx, c, opts.StreamPath)
+	defer stream.Close()
+
+	if ctx.Err() != nil {
+		return nil, errors.Wrapf(ctx.Err(), "stop waiting for deployment '%s'", deploymentID)
+	}
+
+	if stream.HasError() {
+		c.Logger().WithError(stream.Error()).Debugf("DEPLOYMENT:\t%s\t->\tcannot follow stream, polling", deploymentID)
+
+		return nil, errNotFollowed
+	}
+
+	for event := range stream.Payload() {
+		deployment, err := event.Value()
+		if err != nil {
+			continue
+		}
+
+		if deployment.UUID != "" && deployment.UUID != deploymentID {
+			continue
+		}
+
+		progress(deployment, opts)
+
+		if deployment.Done() {
+			return deployment, nil
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil, errors.Wrapf(ctx.Err(), "stop waiting for deployment '%s'", deploymentID)
+	}
+
+	c.Logger().Debugf("DEPLOYMENT:\t%s\t->\tstream ended early, polling", deploymentID)
+
+	return nil, errNotFollowed
+}
+
+func poll(ctx context.Context, c *client.Client, ownerID, applicationID, deploymentID string, opts WaitOptions) (*Deployment, error) {
+	delay := opts.PollInterval
+	start := time.Now()
+
+	for {
+		res := Get(ctx, c, ownerID, applicationID, deploymentID)
+
+		switch {
+		// a deployment may not be visible right after being queued
+		case res.IsNotFoundError() && time.Since(start) < opts.NotFoundGracePeriod:
+		case res.HasError():
+			return nil, errors.Wrapf(res.Error(), "cannot get deployment '%s'", deploymentID)
+		default:
+			deployment := res.Payload()
+			progress(deployment, opts)
+
+			if deployment.Done() {
+				return deployment, nil
+			}
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, errors.Wrapf(ctx.Err(), "stop waiting for deployment '%s'", deploymentID)
+		case <-timer.C:
+		}
+
+		delay += delay / 2
+		if delay > opts.MaxPollInterval {
+			delay = opts.MaxPollInterval
+		}
+	}
+}
+
+func progress(deployment *Deployment, opts WaitOptions) {
+	if opts.OnProgress != nil {
+		opts.OnProgress(deployment)
+	}
+}
@@ -0,0 +1,229 @@
+package deployments_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.clever-cloud.dev/client"
+	"go.clever-cloud.dev/client/deployments"
+)
+
+func Test_WaitForDeployment(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+
+	polls := 0
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path != "/v2/organisations/orga_1/applications/app_1/deployments/deployment_1" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		polls++
+
+		switch polls {
+		case 1:
+			// not visible yet
+			w.WriteHeader(http.StatusNotFound)
+		case 2, 3:
+			_, _ = w.Write([]byte(`{"uuid":"deployment_1","state":"WIP"}`))
+		default:
+			_, _ = w.Write([]byte(`{"uuid":"deployment_1","state":"FAIL"}`))
+		}
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+	states := []string{}
+
+	deployment, err := deployments.WaitForDeployment(context.Background(), c, "orga_1", "app_1", "deployment_1", deployments.WaitOptions{
+		PollInterval: time.Millisecond,
+		// not served, polling takes over
+		StreamPath: "/v2/events",
+		OnProgress: func(deployment *deployments.Deployment) {
+			states = append(states, deployment.State)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if deployment.State != deployments.StateFail {
+		t.Errorf("expect a failed deployment, got %+v", deployment)
+	}
+
+	if len(states) != 3 {
+		t.Errorf("expect 3 progress updates, got %v", states)
+	}
+}
+
+func Test_WaitForDeployment_cancel(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"uuid":"deployment_1","state":"WIP"}`))
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	opts := deployments.WaitOptions{PollInterval: 10 * time.Millisecond}
+	if _, err := deployments.WaitForDeployment(ctx, c, "", "app_1", "deployment_1", opts); err == nil {
+		t.Error("expect an error once the context is done")
+	}
+}
+
+func Test_WaitForDeployment_stream(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/events" {
+			t.Errorf("expect the stream to be followed instead of polling, got %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = fmt.Fprint(w, "data: {\"uuid\":\"deployment_1\",\"state\":\"WIP\"}\n\n")
+		// other deployments and other events are ignored
+		_, _ = fmt.Fprint(w, "data: {\"uuid\":\"deployment_2\",\"state\":\"OK\"}\n\n")
+		_, _ = fmt.Fprint(w, "event: heartbeat\ndata: ping\n\n")
+		_, _ = fmt.Fprint(w, "data: {\"uuid\":\"deployment_1\",\"state\":\"OK\"}\n\n")
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+	states := []string{}
+
+	deployment, err := deployments.WaitForDeployment(context.Background(), c, "orga_1", "app_1", "deployment_1", deployments.WaitOptions{
+		StreamPath: "/v2/events",
+		OnProgress: func(deployment *deployments.Deployment) {
+			states = append(states, deployment.State)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if deployment.State != deployments.StateOK || len(states) != 2 {
+		t.Errorf("unexpected deployment %+v, after updates %v", deployment, states)
+	}
+}
+
+func Test_WaitForDeployment_maxPollInterval(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+
+	polls := []time.Time{}
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		polls = append(polls, time.Now())
+		if len(polls) < 8 {
+			_, _ = w.Write([]byte(`{"uuid":"deployment_1","state":"WIP"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"uuid":"deployment_1","state":"OK"}`))
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+
+	// a maximum below the first interval is raised to it, not replaced by the default
+	opts := deployments.WaitOptions{PollInterval: 20 * time.Millisecond, MaxPollInterval: 10 * time.Millisecond}
+	if _, err := deployments.WaitForDeployment(context.Background(), c, "", "app_1", "deployment_1", opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// growing delays would wait 20+30+45+67+100+150+225ms
+	if total := polls[len(polls)-1].Sub(polls[0]); total > 400*time.Millisecond {
+		t.Errorf("expect polls every 20ms, waited %s for %d polls", total, len(polls))
+	}
+}
+
+func Test_WaitForDeployment_notFound(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+
+	opts := deployments.WaitOptions{PollInterval: 5 * time.Millisecond, NotFoundGracePeriod: 30 * time.Millisecond}
+
+	_, err := deployments.WaitForDeployment(context.Background(), c, "", "app_1", "deployment_typo", opts)
+	if !client.IsNotFound(err) {
+		t.Errorf("expect a not found error once the grace period is over, got %v", err)
+	}
+}
+
+func Test_WaitForDeployment_cancelStream(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/events" {
+			t.Errorf("expect no polling once the context is done, got %s", r.URL.Path)
+
+			return
+		}
+
+		_, _ = fmt.Fprint(w, "data: {\"uuid\":\"deployment_1\",\"state\":\"WIP\"}\n\n")
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	}))
+	defer api.Close()
+
+	var logs bytes.Buffer
+
+	log := logrus.New()
+	log.SetOutput(&logs)
+	log.SetLevel(logrus.DebugLevel)
+
+	c := client.New(client.WithEndpoint(api.URL), client.WithLogger(log))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := deployments.WaitOptions{
+		StreamPath: "/v2/events",
+		// the context is done after the first update
+		OnProgress: func(*deployments.Deployment) { cancel() },
+	}
+
+	if _, err := deployments.WaitForDeployment(ctx, c, "", "app_1", "deployment_1", opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("expect the context error, got %v", err)
+	}
+
+	if strings.Contains(logs.String(), "polling") {
+		t.Errorf("expect no fallback on polling once the context is done, got: %s", logs.String())
+	}
+}
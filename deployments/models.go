@@ -0,0 +1,51 @@
+package deployments
+
+import "time"
+
+// States of a deployment, all but StateWIP are terminal.
+const (
+	StateWIP       = "WIP"
+	StateOK        = "OK"
+	StateFail      = "FAIL"
+	StateCancelled = "CANCELLED"
+)
+
+// Deployment of an application.
+type Deployment struct {
+	ID           int     `json:"id"`
+	UUID         string  `json:"uuid"`
+	Date         int64   `json:"date"`
+	Action       string  `json:"action"`
+	State        string  `json:"state"`
+	Commit       string  `json:"commit"`
+	InstanceType string  `json:"instanceType"`
+	Cause        string  `json:"cause"`
+	Author       *Author `json:"author"`
+	Instances    int     `json:"instances"`
+}
+
+type Author struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Done tells if the deployment reached a terminal state.
+func (d *Deployment) Done() bool {
+	return d.State == StateOK || d.State == StateFail || d.State == StateCancelled
+}
+
+// WaitOptions customize WaitForDeployment.
+type WaitOptions struct {
+	// First delay between polls, default: 2s
+	PollInterval time.Duration
+	// Delay between polls grows up to this one, default: 15s, at least PollInterval
+	MaxPollInterval time.Duration
+	// A deployment may not be visible right after being queued, it is not found for up to this long, default: 30s
+	NotFoundGracePeriod time.Duration
+	// SSE endpoint sending deployments as JSON event data, followed instead of polling.
+	// Polling takes over if the stream fails or ends early.
+	StreamPath string
+	// Called on each deployment update, from the calling goroutine
+	OnProgress func(deployment *Deployment)
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// EnvConfig is the client configuration read from environment variables.
+// Zero values are unset variables.
+type EnvConfig struct {
+	Endpoint         string        // CLEVER_API_URL
+	BridgeEndpoint   string        // CLEVER_BRIDGE_URL
+	Timeout          time.Duration // CLEVER_TIMEOUT, as a Go duration or seconds
+	RetryMaxAttempts int           // CLEVER_RETRY_MAX_ATTEMPTS
+	RetryMinBackoff  time.Duration // CLEVER_RETRY_MIN_BACKOFF
+	RetryMaxBackoff  time.Duration // CLEVER_RETRY_MAX_BACKOFF
+	Proxy            *url.URL      // CLEVER_PROXY
+	LogLevel         *logrus.Level // CLEVER_LOG_LEVEL
+	Debug            bool          // CLEVER_DEBUG
+}
+
+// envParser collect every invalid variable, to report them all at once.
+type envParser struct {
+	failures []string
+}
+
+func (p *envParser) fail(name, value string, reason string) {
+	p.failures = append(p.failures, name+"="+strconv.Quote(value)+": "+reason)
+}
+
+func (p *envParser) url(name string) *url.URL {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		p.fail(name, value, "expect an http(s) URL")
+
+		return nil
+	}
+
+	return u
+}
+
+func (p *envParser) endpoint(name string) string {
+	u := p.url(name)
+	if u == nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(u.String(), "/")
+}
+
+func (p *envParser) duration(name string) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		p.fail(name, value, "expect a positive duration, like '30s' or '30'")
+
+		return 0
+	}
+
+	return d
+}
+
+func (p *envParser) positiveInt(name string) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		p.fail(name, value, "expect a positive integer")
+
+		return 0
+	}
+
+	return n
+}
+
+func (p *envParser) bool(name string) bool {
+	value := os.Getenv(name)
+	if value == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		p.fail(name, value, "expect a boolean")
+
+		return false
+	}
+
+	return b
+}
+
+func (p *envParser) logLevel(name string) *logrus.Level {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+
+	level, err := logrus.ParseLevel(value)
+	if err != nil {
+		p.fail(name, value, "expect one of panic, fatal, error, warn, info, debug, trace")
+
+		return nil
+	}
+
+	return &level
+}
+
+// LoadEnvConfig read and validate client configuration from environment variables.
+// All invalid variables are reported in the returned error.
+func LoadEnvConfig() (*EnvConfig, error) {
+	p := &envParser{}
+
+	conf := &EnvConfig{
+		Endpoint:         p.endpoint("CLEVER_API_URL"),
+		BridgeEndpoint:   p.endpoint("CLEVER_BRIDGE_URL"),
+		Timeout:          p.duration("CLEVER_TIMEOUT"),
+		RetryMaxAttempts: p.positiveInt("CLEVER_RETRY_MAX_ATTEMPTS"),
+		RetryMinBackoff:  p.duration("CLEVER_RETRY_MIN_BACKOFF"),
+		RetryMaxBackoff:  p.duration("CLEVER_RETRY_MAX_BACKOFF"),
+		Proxy:            p.url("CLEVER_PROXY"),
+		LogLevel:         p.logLevel("CLEVER_LOG_LEVEL"),
+		Debug:            p.bool("CLEVER_DEBUG"),
+	}
+
+	if len(p.failures) > 0 {
+		return conf, errors.Errorf("invalid environment configuration: %s", strings.Join(p.failures, "; "))
+	}
+
+	return conf, nil
+}
+
+// apply set the client up with this configuration.
+func (conf *EnvConfig) apply(c *Client) {
+	if conf.LogLevel != nil || conf.Debug {
+		level := logrus.DebugLevel
+		if conf.LogLevel != nil {
+			level = *conf.LogLevel
+		}
+
+		if logger, ok := c.log.(*logrus.Logger); ok && c.ownLogger {
+			// default logger discards everything
+			logger.Out = os.Stderr
+			logger.SetLevel(level)
+		} else {
+			c.log.Warnf("ignoring log level '%s' from environment, the logger is set with WithLogger()", level)
+		}
+	}
+
+	if conf.Endpoint != "" {
+		c.endpoint = conf.Endpoint
+	}
+
+	if conf.BridgeEndpoint != "" {
+		c.bridgeEndpoint = conf.BridgeEndpoint
+	}
+
+	if conf.Timeout > 0 {
+		c.requestTimeout = conf.Timeout
+	}
+
+	if conf.RetryMaxAttempts > 0 || conf.RetryMinBackoff > 0 || conf.RetryMaxBackoff > 0 {
+		policy := DefaultRetryPolicy()
+		if c.retryPolicy != nil {
+			policy = *c.retryPolicy
+		}
+
+		if conf.RetryMaxAttempts > 0 {
+			policy.MaxAttempts = conf.RetryMaxAttempts
+		}
+
+		if conf.RetryMinBackoff > 0 {
+			policy.MinBackoff = conf.RetryMinBackoff
+		}
+
+		if conf.RetryMaxBackoff > 0 {
+			policy.MaxBackoff = conf.RetryMaxBackoff
+		}
+
+		WithRetryPolicy(policy)(c)
+	}
+
+	if conf.Proxy != nil {
+		httpClient, err := withProxy(c.httpClient, conf.Proxy)
+		if err != nil {
+			c.log.WithError(err).Error("cannot configure client from environment")
+			c.configErr = err
+
+			return
+		}
+
+		c.httpClient = httpClient
+	}
+}
+
+// withProxy returns a copy of the HTTP client using the given proxy.
+func withProxy(httpClient *http.Client, proxy *url.URL) (*http.Client, error) {
+	var transport *http.Transport
+
+	switch t := httpClient.Transport.(type) {
+	case nil:
+		transport, _ = http.DefaultTransport.(*http.Transport)
+	case *http.Transport:
+		transport = t
+	default:
+		return nil, errors.Errorf("CLEVER_PROXY cannot be applied on a custom HTTP transport (%T)", t)
+	}
+
+	transport = transport.Clone()
+	transport.Proxy = http.ProxyURL(proxy)
+
+	clone := *httpClient
+	clone.Transport = transport
+
+	return &clone, nil
+}
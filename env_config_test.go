@@ -0,0 +1,91 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.clever-cloud.dev/client"
+)
+
+func Test_WithEnvConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("CLEVER_API_URL", srv.URL+"/")
+	t.Setenv("CLEVER_TIMEOUT", "50ms")
+	t.Setenv("CLEVER_RETRY_MAX_ATTEMPTS", "2")
+
+	c := client.New(client.WithEnvConfig())
+	if c.Err() != nil {
+		t.Fatalf("unexpected configuration error: %v", c.Err())
+	}
+
+	if res := client.Get[client.Nothing](context.Background(), c, "/v2/self"); res.HasError() {
+		t.Errorf("expect request to be sent to CLEVER_API_URL, got: %v", res.Error())
+	}
+
+	if res := client.Get[client.Nothing](context.Background(), c, "/slow"); !res.HasError() {
+		t.Errorf("expect CLEVER_TIMEOUT to be applied")
+	}
+}
+
+func Test_WithEnvConfig_invalid(t *testing.T) {
+	t.Setenv("CLEVER_API_URL", "api.clever-cloud.com")
+	t.Setenv("CLEVER_TIMEOUT", "forever")
+	t.Setenv("CLEVER_LOG_LEVEL", "verbose")
+
+	c := client.New(client.WithEnvConfig())
+	if c.Err() == nil {
+		t.Fatal("expect a configuration error")
+	}
+
+	for _, name := range []string{"CLEVER_API_URL", "CLEVER_TIMEOUT", "CLEVER_LOG_LEVEL"} {
+		if !strings.Contains(c.Err().Error(), name) {
+			t.Errorf("expect %s to be reported, got: %v", name, c.Err())
+		}
+	}
+
+	if res := client.Get[client.Nothing](context.Background(), c, "/v2/self"); res.Error() != c.Err() {
+		t.Errorf("expect requests to fail with the configuration error, got: %v", res.Error())
+	}
+}
+
+func Test_WithEnvConfig_logLevel(t *testing.T) {
+	t.Setenv("CLEVER_LOG_LEVEL", "debug")
+
+	c := client.New(client.WithEnvConfig())
+	if logger, ok := c.Logger().(*logrus.Logger); !ok || logger.Level != logrus.DebugLevel || logger.Out != os.Stderr {
+		t.Errorf("expect default logger to log debug messages on stderr, got %+v", c.Logger())
+	}
+}
+
+func Test_WithEnvConfig_customLogger(t *testing.T) {
+	t.Setenv("CLEVER_LOG_LEVEL", "debug")
+
+	var logs bytes.Buffer
+
+	log := logrus.New()
+	log.SetOutput(&logs)
+	log.SetLevel(logrus.WarnLevel)
+
+	client.New(client.WithLogger(log), client.WithEnvConfig())
+
+	if log.Level != logrus.WarnLevel || log.Out != &logs {
+		t.Errorf("expect a custom logger to be left as is, got level %s", log.Level)
+	}
+
+	if !strings.Contains(logs.String(), "ignoring log level 'debug'") {
+		t.Errorf("expect a warning, got: %s", logs.String())
+	}
+}
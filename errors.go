@@ -0,0 +1,105 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// APIError is returned when CleverCloud API answer with an error status code.
+// Use errors.As() to get it from a response error.
+type APIError struct {
+	// Transport metadata, never read from the body
+	StatusCode int        `json:"-"`
+	SozuID     string     `json:"-"`
+	Method     string     `json:"-"`
+	URL        string     `json:"-"`
+	RateLimit  *RateLimit `json:"-"`
+
+	// Decoded CleverCloud error body, if any
+	ID      int               `json:"id"`
+	Message string            `json:"message"`
+	Type    string            `json:"type"`
+	Fields  map[string]string `json:"fields"`
+
+	// Raw response body
+	Body []byte `json:"-"`
+}
+
+func newAPIError(httpRes *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: httpRes.StatusCode,
+		SozuID:     httpRes.Header.Get("Sozu-Id"),
+		RateLimit:  parseRateLimit(httpRes.Header, time.Now()),
+		Body:       body,
+	}
+
+	if httpRes.Request != nil {
+		apiErr.Method = httpRes.Request.Method
+		apiErr.URL = httpRes.Request.URL.String()
+	}
+
+	// best effort, some endpoints do not answer with the usual error format
+	_ = json.Unmarshal(body, apiErr)
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := string(e.Body)
+	if e.Message != "" {
+		msg = fmt.Sprintf("%d: %s", e.ID, e.Message)
+	}
+
+	return fmt.Sprintf("invalid response from CleverCloud API (status=%d): %s", e.StatusCode, msg)
+}
+
+func asAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr == nil {
+		return nil, false
+	}
+
+	return apiErr, true
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	apiErr, ok := asAPIError(err)
+
+	return ok && apiErr.StatusCode == statusCode
+}
+
+// IsUnauthorized tells if the API rejected the credentials (401).
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden tells if the API denied access to the resource (403).
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsNotFound tells if the resource does not exist (404).
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsConflict tells if the request conflicts with the resource state (409).
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+// IsRateLimited tells if the request was throttled (429).
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+// IsServerError tells if the API failed to handle the request (5xx).
+func IsServerError(err error) bool {
+	apiErr, ok := asAPIError(err)
+
+	return ok && apiErr.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,77 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_APIError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Sozu-Id", "sozu-42")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"id":3001,"message":"name already used","type":"error","fields":{"name":"duplicate"}}`))
+	}))
+	defer srv.Close()
+
+	c := client.New(client.WithEndpoint(srv.URL))
+
+	res := client.Post[client.Nothing](context.Background(), c, "/v2/organisations", map[string]string{"name": "foo"})
+	if !res.HasError() {
+		t.Fatalf("expect an error")
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(res.Error(), &apiErr) {
+		t.Fatalf("expect an *APIError, got %T", res.Error())
+	}
+
+	if apiErr.StatusCode != http.StatusConflict || apiErr.SozuID != "sozu-42" ||
+		apiErr.Method != http.MethodPost || apiErr.URL != srv.URL+"/v2/organisations" ||
+		apiErr.ID != 3001 || apiErr.Message != "name already used" || apiErr.Fields["name"] != "duplicate" {
+		t.Errorf("unexpected API error: %+v", apiErr)
+	}
+
+	if !client.IsConflict(res.Error()) || client.IsNotFound(res.Error()) || client.IsServerError(res.Error()) {
+		t.Errorf("helpers does not match status code %d", apiErr.StatusCode)
+	}
+
+	if client.IsConflict(errors.New("conflict")) {
+		t.Errorf("helpers must not match non API errors")
+	}
+}
+
+func Test_APIError_bodyDoesNotOverrideMetadata(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Sozu-Id", "sozu-42")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"id":4000,"message":"bad","StatusCode":200,"statusCode":200,"SozuID":"fake","Method":"PUT","url":"https://evil.example.com","URL":"https://evil.example.com","RateLimit":{"Limit":1}}`))
+	}))
+	defer srv.Close()
+
+	c := client.New(client.WithEndpoint(srv.URL))
+
+	res := client.Get[client.Nothing](context.Background(), c, "/v2/self")
+
+	var apiErr *client.APIError
+	if !errors.As(res.Error(), &apiErr) {
+		t.Fatalf("expect an *APIError, got %T", res.Error())
+	}
+
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.SozuID != "sozu-42" ||
+		apiErr.Method != http.MethodGet || apiErr.URL != srv.URL+"/v2/self" || apiErr.RateLimit != nil {
+		t.Errorf("transport metadata overridden by the body: %+v", apiErr)
+	}
+
+	if apiErr.ID != 4000 || apiErr.Message != "bad" {
+		t.Errorf("expect the body to be decoded: %+v", apiErr)
+	}
+}
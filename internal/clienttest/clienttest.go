@@ -0,0 +1,92 @@
+// Package clienttest serves fake API endpoints to test service packages.
+package clienttest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.clever-cloud.dev/client"
+)
+
+// Endpoint answers requests with the given method and path.
+type Endpoint struct {
+	Method string
+	Path   string
+	// Answer status, default: 200, or 204 without Response
+	Status int
+	// JSON answer body
+	Response string
+	// Assert on the request, optional, it runs on the server goroutine: use t.Error, not t.Fatal
+	Check func(t *testing.T, r *http.Request)
+}
+
+// NewClient returns a client of a fake API serving the given endpoints.
+// Unknown endpoints answer 404, and fail the test.
+func NewClient(t *testing.T, endpoints ...Endpoint) *client.Client {
+	t.Helper()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, endpoint := range endpoints {
+			if endpoint.Method != r.Method || endpoint.Path != r.URL.Path {
+				continue
+			}
+
+			if endpoint.Check != nil {
+				endpoint.Check(t, r)
+			}
+
+			status := endpoint.Status
+			if status == 0 {
+				status = http.StatusOK
+				if endpoint.Response == "" {
+					status = http.StatusNoContent
+				}
+			}
+
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(endpoint.Response))
+
+			return
+		}
+
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(api.Close)
+
+	return client.New(client.WithEndpoint(api.URL))
+}
+
+// DecodeBody JSON-decodes the request body into v.
+func DecodeBody(t *testing.T, r *http.Request, v interface{}) {
+	t.Helper()
+
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		t.Errorf("cannot decode request body: %v", err)
+	}
+}
+
+// Payload returns the response payload, the test stops if the response has an error.
+func Payload[T any](t *testing.T, res client.Response[T]) *T {
+	t.Helper()
+
+	if res.HasError() {
+		t.Fatalf("unexpected error: %v", res.Error())
+	}
+
+	return res.Payload()
+}
+
+// Items returns the items of a list response, the test stops if there is not exactly n of them.
+func Items[T any](t *testing.T, res client.Response[[]T], n int) []T {
+	t.Helper()
+
+	items := *Payload(t, res)
+	if len(items) != n {
+		t.Fatalf("expect %d items, got %d: %+v", n, len(items), items)
+	}
+
+	return items
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket, shared by all requests of a client.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait block until a token is available, or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		l.last = now
+
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+
+			return nil
+		}
+
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// semaphore limit the number of in-flight requests.
+type semaphore chan struct{}
+
+func (s semaphore) acquire(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s semaphore) release() {
+	<-s
+}
+
+// releaseOnClose gives back a semaphore slot once the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+
+	return err
+}
+
+// limitMiddleware waits for the client rate limiter and concurrency cap.
+// A concurrency slot is held until the response body is closed, so for its whole life on streams.
+func (c *Client) limitMiddleware(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		if c.concurrency == nil {
+			return next.Do(req)
+		}
+
+		if err := c.concurrency.acquire(ctx); err != nil {
+			return nil, err
+		}
+
+		res, err := next.Do(req)
+		if err != nil {
+			c.concurrency.release()
+
+			return nil, err
+		}
+
+		res.Body = &releaseOnClose{ReadCloser: res.Body, release: c.concurrency.release}
+
+		return res, nil
+	})
+}
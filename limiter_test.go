@@ -0,0 +1,91 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_client_MaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var current, peak int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithMaxConcurrency(2),
+	)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if res := client.Get[client.Nothing](context.Background(), c, "/"); res.HasError() {
+				t.Errorf("unexpected error: %v", res.Error())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&peak); got > 2 {
+		t.Errorf("expect at most 2 concurrent requests, got %d", got)
+	}
+}
+
+func Test_client_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithRateLimit(20, 1),
+	)
+
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if res := client.Get[client.Nothing](context.Background(), c, "/"); res.HasError() {
+			t.Fatalf("unexpected error: %v", res.Error())
+		}
+	}
+
+	// first token is available immediately, then one every 50ms
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expect requests to be throttled, took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if res := client.Get[client.Nothing](ctx, c, "/"); !res.HasError() {
+		t.Errorf("expect waiting for a token to respect context cancellation")
+	}
+}
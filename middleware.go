@@ -0,0 +1,94 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	otel "go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace"
+)
+
+// Doer sends an HTTP request, *http.Client is a Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to use a function as a Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to add behaviour around each request.
+// Requests are built for each attempt, a middleware can modify them in place.
+type Middleware func(next Doer) Doer
+
+// chain build the Doer shared by unary requests and streams.
+// From the outermost to the innermost:
+//   - OpenTelemetry context injection
+//   - User-Agent header
+//   - user middlewares, in the order they were given
+//   - authentication, so that changes made by user middlewares are signed
+//   - logging
+//   - rate limiting and concurrency cap
+//   - the HTTP client
+func (c *Client) chain() Doer {
+	middlewares := []Middleware{telemetryMiddleware, userAgentMiddleware}
+	middlewares = append(middlewares, c.middlewares...)
+	middlewares = append(middlewares, c.authMiddleware, c.loggingMiddleware, c.limitMiddleware)
+
+	var doer Doer = DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return c.httpClient.Do(req)
+	})
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+
+	return doer
+}
+
+func telemetryMiddleware(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		otel.Inject(req.Context(), req)
+
+		return next.Do(req)
+	})
+}
+
+func userAgentMiddleware(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("User-Agent", userAgent())
+
+		return next.Do(req)
+	})
+}
+
+func (c *Client) authMiddleware(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		auth := c.Authenticator()
+		if auth == nil {
+			return next.Do(req)
+		}
+
+		if err := asContextAuthenticator(auth).SignRequest(req.Context(), req); err != nil {
+			return nil, &signError{errors.Wrap(err, "failed to authenticate request")}
+		}
+
+		return next.Do(req)
+	})
+}
+
+func (c *Client) loggingMiddleware(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		res, err := next.Do(req)
+		if err != nil {
+			c.log.Warnf("RESPONSE:\t%s\t%s\t->\t%+v", req.Method, req.URL.String(), err.Error())
+		} else {
+			c.log.Infof("RESPONSE:\t%s\t%s\t->\t%s", req.Method, req.URL.String(), res.Status)
+		}
+
+		return res, err
+	})
+}
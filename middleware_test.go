@@ -0,0 +1,62 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_client_Middleware(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Audit") != "first,second" {
+			t.Errorf("expect middlewares to be called in order, got X-Audit=%q", r.Header.Get("X-Audit"))
+		}
+	}))
+	defer srv.Close()
+
+	var calls []string
+
+	audit := func(name string) client.Middleware {
+		return func(next client.Doer) client.Doer {
+			return client.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				// built-in User-Agent is set before, authentication after
+				if !strings.Contains(req.Header.Get("User-Agent"), "cc-client/") {
+					t.Errorf("%s: expect User-Agent to be set", name)
+				}
+
+				if req.Header.Get("Authorization") != "" {
+					t.Errorf("%s: expect request not to be signed yet", name)
+				}
+
+				if prev := req.Header.Get("X-Audit"); prev != "" {
+					name = prev + "," + name
+				}
+
+				req.Header.Set("X-Audit", name)
+				calls = append(calls, req.URL.Path)
+
+				return next.Do(req)
+			})
+		}
+	}
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithBearerAuth("token"),
+		client.WithMiddleware(audit("first"), audit("second")),
+	)
+
+	if res := client.Get[client.Nothing](context.Background(), c, "/v2/self"); res.HasError() {
+		t.Fatalf("unexpected error: %v", res.Error())
+	}
+
+	if len(calls) != 2 {
+		t.Errorf("expect each middleware to be called once, got %v", calls)
+	}
+}
@@ -1,7 +1,9 @@
 package client
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -13,10 +15,18 @@ func WithEndpoint(endpoint string) func(*Client) {
 	}
 }
 
+// Set bridge API endoint, used by API tokens management, default: BRIDGE_API_ENDPOINT.
+func WithBridgeEndpoint(endpoint string) func(*Client) {
+	return func(c *Client) {
+		c.bridgeEndpoint = endpoint
+	}
+}
+
 // Set a logger, default: discard.
 func WithLogger(logger logrus.FieldLogger) func(*Client) {
 	return func(c *Client) {
 		c.log = logger
+		c.ownLogger = false
 	}
 }
 
@@ -53,32 +63,30 @@ func WithUserOauthConfig(accessToken, accessSecret string) func(*Client) {
 
 // Set OAuth1 credentials from environment, default: none.
 func WithAutoOauthConfig() func(*Client) {
-	return func(c *Client) {
-		conf := c.GuessOauth1Config()
-		if conf == nil {
-			return
-		}
-
-		if conf.ConsumerKey == "" {
-			conf.ConsumerKey = OAUTH_CONSUMER_KEY
-		}
+	return WithCredentialProvider(NewChainProvider(
+		EnvOAuth1Provider(),
+		CleverToolsFileProvider(),
+	))
+}
 
-		if conf.ConsumerSecret == "" {
-			conf.ConsumerSecret = OAUTH_CONSUMER_SECRET
-		}
+// Set OAuth1 credentials from environment, or bearer token with the bridge endpoint, default: none.
+// When no credentials are found, the endpoint is kept and requests are sent without authentication.
+func WithAutoAuthConfig() func(*Client) {
+	return WithCredentialProvider(DefaultCredentialProvider())
+}
 
-		c.authenticator = conf
-	}
+// Set credentials and endpoint from a profile, default: none.
+// An empty name selects CC_PROFILE env var, then the default profile.
+func WithProfile(name string) func(*Client) {
+	return WithCredentialProvider(NewProfileProvider(name))
 }
 
-// Set OAuth1 credentials from environment, default: none.
-func WithAutoAuthConfig() func(*Client) {
+// Set credentials from a provider, default: none.
+// A bearer token switches the default endpoint to the bridge one.
+func WithCredentialProvider(provider CredentialProvider) func(*Client) {
 	return func(c *Client) {
-		WithAutoOauthConfig()(c)
-
-		if c.authenticator == nil {
-			WithEndpoint(BRIDGE_API_ENDPOINT)(c)
-			WithBearerAuth("")(c)
+		if err := c.useCredentialProvider(context.Background(), provider); err != nil {
+			c.logCredentialsError(err)
 		}
 	}
 }
@@ -96,3 +104,71 @@ func WithBearerAuth(token string) func(c *Client) {
 		}
 	}
 }
+
+// Retry failed requests according to the given policy, default: no retry.
+func WithRetryPolicy(policy RetryPolicy) func(*Client) {
+	return func(c *Client) {
+		p := policy.withDefaults()
+		c.retryPolicy = &p
+	}
+}
+
+// Limit the rate of requests sent by this client, default: unlimited.
+// Allow rps requests per second on average, with bursts of up to burst requests.
+func WithRateLimit(rps float64, burst int) func(*Client) {
+	return func(c *Client) {
+		if rps <= 0 {
+			c.rateLimiter = nil
+
+			return
+		}
+
+		c.rateLimiter = newRateLimiter(rps, burst)
+	}
+}
+
+// Limit the number of in-flight requests, including open streams, default: unlimited.
+func WithMaxConcurrency(n int) func(*Client) {
+	return func(c *Client) {
+		if n <= 0 {
+			c.concurrency = nil
+
+			return
+		}
+
+		c.concurrency = make(semaphore, n)
+	}
+}
+
+// Add middlewares around each request, default: none.
+// Middlewares are called in the given order, see Client.chain() for their place among built-in ones.
+func WithMiddleware(middlewares ...Middleware) func(*Client) {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// Bound the duration of each request, streams excepted, default: none.
+// WithTimeout() request option takes precedence.
+func WithRequestTimeout(timeout time.Duration) func(*Client) {
+	return func(c *Client) {
+		c.requestTimeout = timeout
+	}
+}
+
+// Configure the client from environment variables, see EnvConfig.
+// Invalid variables are logged, and make all requests fail with an error listing them, see Client.Err().
+// Log level only applies to the default logger, a logger given with WithLogger() is left as is.
+func WithEnvConfig() func(*Client) {
+	return func(c *Client) {
+		conf, err := LoadEnvConfig()
+		if err != nil {
+			c.log.WithError(err).Error("cannot configure client from environment")
+			c.configErr = err
+
+			return
+		}
+
+		conf.apply(c)
+	}
+}
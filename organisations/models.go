@@ -0,0 +1,75 @@
+package organisations
+
+// Organisation is a billing entity which owns applications and add-ons.
+type Organisation struct {
+	ID               string  `json:"id"`
+	Name             string  `json:"name"`
+	Description      string  `json:"description"`
+	BillingEmail     string  `json:"billingEmail"`
+	Address          string  `json:"address"`
+	City             string  `json:"city"`
+	ZipCode          string  `json:"zipcode"`
+	Country          string  `json:"country"`
+	Company          string  `json:"company"`
+	VAT              string  `json:"VAT"`
+	Avatar           *string `json:"avatar"`
+	VATState         string  `json:"vatState"`
+	CustomerFullName string  `json:"customerFullName"`
+	CanPay           bool    `json:"canPay"`
+	CleverEnterprise bool    `json:"cleverEnterprise"`
+	EmergencyNumber  *string `json:"emergencyNumber"`
+	CanSEPA          bool    `json:"canSEPA"`
+	IsTrusted        bool    `json:"isTrusted"`
+}
+
+// OrganisationRequest holds fields to set on creation or update, empty ones are left untouched.
+type OrganisationRequest struct {
+	Name             string `json:"name,omitempty"`
+	Description      string `json:"description,omitempty"`
+	BillingEmail     string `json:"billingEmail,omitempty"`
+	Address          string `json:"address,omitempty"`
+	City             string `json:"city,omitempty"`
+	ZipCode          string `json:"zipcode,omitempty"`
+	Country          string `json:"country,omitempty"`
+	Company          string `json:"company,omitempty"`
+	VAT              string `json:"VAT,omitempty"`
+	CustomerFullName string `json:"customerFullName,omitempty"`
+}
+
+// Role of a member in an organisation.
+type Role string
+
+const (
+	RoleAdmin      Role = "ADMIN"
+	RoleManager    Role = "MANAGER"
+	RoleDeveloper  Role = "DEVELOPER"
+	RoleAccounting Role = "ACCOUNTING"
+)
+
+// Member is a user of an organisation, with its role.
+type Member struct {
+	Member MemberUser `json:"member"`
+	Role   Role       `json:"role"`
+	Job    *string    `json:"job"`
+}
+
+type MemberUser struct {
+	ID           string  `json:"id"`
+	Email        string  `json:"email"`
+	Name         string  `json:"name"`
+	Avatar       *string `json:"avatar"`
+	PreferredMFA *string `json:"preferredMFA"`
+}
+
+// Invitation to join an organisation, sent by email.
+type Invitation struct {
+	Email string `json:"email"`
+	Role  Role   `json:"role"`
+	Job   string `json:"job,omitempty"`
+}
+
+// MemberUpdate change the role or job of a member.
+type MemberUpdate struct {
+	Role Role   `json:"role"`
+	Job  string `json:"job,omitempty"`
+}
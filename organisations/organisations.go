@@ -0,0 +1,78 @@
+// Package organisations manage organisations and their members.
+package organisations
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"go.clever-cloud.dev/client"
+	"go.clever-cloud.dev/client/self"
+)
+
+func organisationPath(organisationID string) string {
+	return fmt.Sprintf("/v2/organisations/%s", url.PathEscape(organisationID))
+}
+
+// List organisations the current user is a member of.
+func List(ctx context.Context, c *client.Client) client.Response[[]Organisation] {
+	return client.Get[[]Organisation](ctx, c, "/v2/organisations")
+}
+
+// Get an organisation.
+func Get(ctx context.Context, c *client.Client, organisationID string) client.Response[Organisation] {
+	return client.Get[Organisation](ctx, c, organisationPath(organisationID))
+}
+
+// Create an organisation, the current user is its first admin.
+func Create(ctx context.Context, c *client.Client, organisation OrganisationRequest) client.Response[Organisation] {
+	return client.Post[Organisation](ctx, c, "/v2/organisations", organisation)
+}
+
+// Update an organisation.
+func Update(ctx context.Context, c *client.Client, organisationID string, organisation OrganisationRequest) client.Response[Organisation] {
+	return client.Put[Organisation](ctx, c, organisationPath(organisationID), organisation)
+}
+
+// Delete an organisation, it must not own any application or add-on.
+func Delete(ctx context.Context, c *client.Client, organisationID string) client.Response[client.Nothing] {
+	return client.Delete[client.Nothing](ctx, c, organisationPath(organisationID))
+}
+
+// ListMembers of an organisation.
+func ListMembers(ctx context.Context, c *client.Client, organisationID string) client.Response[[]Member] {
+	return client.Get[[]Member](ctx, c, organisationPath(organisationID)+"/members")
+}
+
+// InviteMember send an invitation to join the organisation.
+func InviteMember(ctx context.Context, c *client.Client, organisationID string, invitation Invitation) client.Response[client.Nothing] {
+	return client.Post[client.Nothing](ctx, c, organisationPath(organisationID)+"/members", invitation)
+}
+
+// UpdateMember change the role of a member.
+func UpdateMember(ctx context.Context, c *client.Client, organisationID, userID string, update MemberUpdate) client.Response[client.Nothing] {
+	path := fmt.Sprintf("%s/members/%s", organisationPath(organisationID), url.PathEscape(userID))
+
+	return client.Put[client.Nothing](ctx, c, path, update)
+}
+
+// RemoveMember from an organisation.
+func RemoveMember(ctx context.Context, c *client.Client, organisationID, userID string) client.Response[client.Nothing] {
+	path := fmt.Sprintf("%s/members/%s", organisationPath(organisationID), url.PathEscape(userID))
+
+	return client.Delete[client.Nothing](ctx, c, path)
+}
+
+// GetSummary returns applications and add-ons of an organisation, from the user summary.
+func GetSummary(ctx context.Context, c *client.Client, organisationID string) client.Response[self.SummaryOrganisation] {
+	return client.Map(self.GetSummary(ctx, c), func(summary *self.Summary) (self.SummaryOrganisation, error) {
+		for _, organisation := range summary.Organisations {
+			if organisation.ID == organisationID {
+				return organisation, nil
+			}
+		}
+
+		return self.SummaryOrganisation{}, errors.Errorf("organisation '%s' is not in the summary of the current user", organisationID)
+	})
+}
@@ -0,0 +1,103 @@
+package organisations_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.clever-cloud.dev/client/internal/clienttest"
+	"go.clever-cloud.dev/client/organisations"
+)
+
+func Test_Create(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodPost,
+		Path:     "/v2/organisations",
+		Response: `{"id":"orga_1","name":"Acme"}`,
+		Check: func(t *testing.T, r *http.Request) {
+			var body map[string]string
+			if clienttest.DecodeBody(t, r, &body); len(body) != 1 || body["name"] != "Acme" {
+				t.Errorf("expect only the name to be sent, got %+v", body)
+			}
+		},
+	})
+
+	organisation := clienttest.Payload(t, organisations.Create(context.Background(), c, organisations.OrganisationRequest{Name: "Acme"}))
+	if organisation.ID != "orga_1" {
+		t.Errorf("unexpected organisation: %+v", organisation)
+	}
+}
+
+func Test_ListMembers(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/organisations/orga_1/members",
+		Response: `[{"member":{"id":"user_1","email":"me@example.com"},"role":"ADMIN"}]`,
+	})
+
+	members := clienttest.Items(t, organisations.ListMembers(context.Background(), c, "orga_1"), 1)
+	if members[0].Role != organisations.RoleAdmin || members[0].Member.ID != "user_1" {
+		t.Errorf("unexpected members: %+v", members)
+	}
+}
+
+func Test_Members(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t,
+		clienttest.Endpoint{
+			Method: http.MethodPost,
+			Path:   "/v2/organisations/orga_1/members",
+			Check: func(t *testing.T, r *http.Request) {
+				var invitation organisations.Invitation
+				if clienttest.DecodeBody(t, r, &invitation); invitation.Role != organisations.RoleDeveloper {
+					t.Errorf("unexpected invitation: %+v", invitation)
+				}
+			},
+		},
+		clienttest.Endpoint{
+			Method: http.MethodPut,
+			Path:   "/v2/organisations/orga_1/members/user_2",
+			Check: func(t *testing.T, r *http.Request) {
+				var update organisations.MemberUpdate
+				if clienttest.DecodeBody(t, r, &update); update.Role != organisations.RoleManager {
+					t.Errorf("unexpected update: %+v", update)
+				}
+			},
+		},
+		clienttest.Endpoint{Method: http.MethodDelete, Path: "/v2/organisations/orga_1/members/user_2"},
+	)
+	ctx := context.Background()
+
+	invitation := organisations.Invitation{Email: "dev@example.com", Role: organisations.RoleDeveloper}
+	clienttest.Payload(t, organisations.InviteMember(ctx, c, "orga_1", invitation))
+
+	update := organisations.MemberUpdate{Role: organisations.RoleManager}
+	clienttest.Payload(t, organisations.UpdateMember(ctx, c, "orga_1", "user_2", update))
+
+	clienttest.Payload(t, organisations.RemoveMember(ctx, c, "orga_1", "user_2"))
+}
+
+func Test_GetSummary(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/summary",
+		Response: `{"user":{"id":"user_1"},"organisations":[{"id":"orga_1","name":"Acme","addons":[{"id":"addon_1"}]}]}`,
+	})
+	ctx := context.Background()
+
+	summary := clienttest.Payload(t, organisations.GetSummary(ctx, c, "orga_1"))
+	if len(summary.Addons) != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	if res := organisations.GetSummary(ctx, c, "orga_2"); !res.HasError() {
+		t.Error("expect an error for an unknown organisation")
+	}
+}
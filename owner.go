@@ -0,0 +1,18 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// OwnerPath returns the path prefix of resources owned by a user or an organisation.
+// Service packages take owner IDs resolved by it: an organisation ID (orga_...),
+// or an empty owner or a user ID (user_...) which designate the current user.
+func OwnerPath(ownerID string) string {
+	if ownerID == "" || strings.HasPrefix(ownerID, "user_") {
+		return "/v2/self"
+	}
+
+	return fmt.Sprintf("/v2/organisations/%s", url.PathEscape(ownerID))
+}
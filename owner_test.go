@@ -0,0 +1,24 @@
+package client_test
+
+import (
+	"testing"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_OwnerPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":             "/v2/self",
+		"user_1234":    "/v2/self",
+		"orga_1234":    "/v2/organisations/orga_1234",
+		"orga_12/../x": "/v2/organisations/orga_12%2F..%2Fx",
+	}
+
+	for ownerID, expected := range tests {
+		if path := client.OwnerPath(ownerID); path != expected {
+			t.Errorf("OwnerPath(%q) = %q, expect %q", ownerID, path, expected)
+		}
+	}
+}
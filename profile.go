@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Profile is a named set of credentials, with the endpoint to use them against.
+type Profile struct {
+	// "oauth1" or "bearer"
+	Kind           string `json:"kind"`
+	Endpoint       string `json:"endpoint,omitempty"`
+	Token          string `json:"token"`
+	Secret         string `json:"secret,omitempty"`
+	ConsumerKey    string `json:"consumerKey,omitempty"`
+	ConsumerSecret string `json:"consumerSecret,omitempty"`
+}
+
+// ProfilesFile is the content of the profiles file:
+//
+//	{
+//	  "default": "personal",
+//	  "profiles": {
+//	    "personal": {"kind": "oauth1", "token": "...", "secret": "..."},
+//	    "ci": {"kind": "bearer", "token": "...", "endpoint": "https://api-bridge.clever-cloud.com"}
+//	  }
+//	}
+type ProfilesFile struct {
+	// Profile used when none is selected
+	Default  string             `json:"default,omitempty"`
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// Authenticator returns credentials of this profile.
+func (p *Profile) Authenticator() (Authenticator, error) {
+	switch p.Kind {
+	case "oauth1", "":
+		if p.Token == "" || p.Secret == "" {
+			return nil, errors.Wrap(ErrNoCredentials, "empty OAuth1 credentials")
+		}
+
+		conf := &OAuth1Config{
+			ConsumerKey:    p.ConsumerKey,
+			ConsumerSecret: p.ConsumerSecret,
+			AccessToken:    p.Token,
+			AccessSecret:   p.Secret,
+		}
+
+		return conf.withDefaultConsumer(), nil
+	case "bearer":
+		if p.Token == "" {
+			return nil, errors.Wrap(ErrNoCredentials, "empty bearer token")
+		}
+
+		return &BearerConfig{Token: p.Token}, nil
+	default:
+		return nil, errors.Errorf("unknown credentials kind '%s'", p.Kind)
+	}
+}
+
+// readProfilesFile load the profiles file, an empty one if it does not exist.
+func readProfilesFile() (*ProfilesFile, error) {
+	return loadProfilesFile(readConfigFile)
+}
+
+// loadProfilesFile load the profiles file with the given reader, an empty one if it does not exist.
+func loadProfilesFile(read func(path string) ([]byte, error)) (*ProfilesFile, error) {
+	profiles := &ProfilesFile{Profiles: map[string]Profile{}}
+
+	path := configFilePath(PROFILES_FILE_NAME)
+	if path == "" {
+		return profiles, nil
+	}
+
+	content, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, profiles); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse profiles file '%s'", path)
+	}
+
+	if profiles.Profiles == nil {
+		profiles.Profiles = map[string]Profile{}
+	}
+
+	return profiles, nil
+}
+
+// LoadProfile returns the profile with the given name.
+// If name is empty, CC_PROFILE env var or the profiles file default is used.
+// The legacy clever-tools configuration file is read as DEFAULT_PROFILE when it is not in the profiles file.
+func LoadProfile(name string) (string, *Profile, error) {
+	profiles, err := readProfilesFile()
+	if err != nil {
+		return "", nil, err
+	}
+
+	explicit := true
+
+	if name == "" {
+		name = os.Getenv("CC_PROFILE")
+	}
+
+	if name == "" {
+		explicit = false
+		name = profiles.Default
+	}
+
+	if name == "" {
+		name = DEFAULT_PROFILE
+	}
+
+	if profile, ok := profiles.Profiles[name]; ok {
+		return name, &profile, nil
+	}
+
+	if name == DEFAULT_PROFILE {
+		conf, err := oauth1ConfigFromConfigFile()
+		if err != nil {
+			return "", nil, err
+		}
+
+		return name, &Profile{
+			Kind:   "oauth1",
+			Token:  conf.AccessToken,
+			Secret: conf.AccessSecret,
+		}, nil
+	}
+
+	if !explicit {
+		return "", nil, errors.Wrapf(ErrNoCredentials, "default profile '%s' does not exist", name)
+	}
+
+	return "", nil, errors.Errorf("profile '%s' does not exist, known profiles: %v", name, profiles.names())
+}
+
+func (f *ProfilesFile) names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ProfileProvider load credentials from a profile, see LoadProfile().
+type ProfileProvider struct {
+	name string
+
+	mu       sync.Mutex
+	loaded   string
+	endpoint string
+}
+
+// NewProfileProvider use the named profile, or the selected one if name is empty.
+func NewProfileProvider(name string) *ProfileProvider {
+	return &ProfileProvider{name: name}
+}
+
+// Name returns the profile name, once retrieved.
+func (p *ProfileProvider) Name() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	name := p.loaded
+	if name == "" {
+		name = p.name
+	}
+
+	return fmt.Sprintf("profile '%s'", name)
+}
+
+// Endpoint returns the endpoint of the profile, once retrieved.
+func (p *ProfileProvider) Endpoint() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.endpoint
+}
+
+func (p *ProfileProvider) Retrieve(ctx context.Context) (Authenticator, error) {
+	name, profile, err := LoadProfile(p.name)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := profile.Authenticator()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid profile '%s'", name)
+	}
+
+	p.mu.Lock()
+	p.loaded = name
+	p.endpoint = profile.Endpoint
+	p.mu.Unlock()
+
+	return auth, nil
+}
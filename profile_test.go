@@ -0,0 +1,99 @@
+package client_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"go.clever-cloud.dev/client"
+)
+
+// withConfigHome isolate user configuration files in a temporary directory.
+func withConfigHome(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	home := t.TempDir()
+	dir := filepath.Join(home, ".config", client.CONFIG_DIR)
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Cleanup(xdg.Reload)
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("CC_PROFILE", "")
+	t.Setenv("CC_OAUTH_TOKEN", "")
+	t.Setenv("CLEVER_API_TOKEN", "")
+	xdg.Reload()
+
+	return dir
+}
+
+func Test_WithProfile(t *testing.T) {
+	withConfigHome(t, map[string]string{
+		client.CONFIG_FILE_NAME: `{"token":"legacy-token","secret":"legacy-secret"}`,
+		client.PROFILES_FILE_NAME: `{
+			"profiles": {
+				"ci": {"kind": "bearer", "token": "ci-token", "endpoint": "https://bridge.example.com"},
+				"staging": {"kind": "oauth1", "token": "staging-token", "secret": "s", "endpoint": "https://staging.example.com"}
+			}
+		}`,
+	})
+
+	tests := []struct {
+		name       string
+		profile    string
+		env        string
+		wantSource string
+		wantToken  string
+	}{{
+		name:       "legacy file is the default profile",
+		wantSource: "profile 'default'",
+		wantToken:  "legacy-token",
+	}, {
+		name:       "explicit profile",
+		profile:    "ci",
+		wantSource: "profile 'ci'",
+		wantToken:  "ci-token",
+	}, {
+		name:       "profile from env",
+		env:        "staging",
+		wantSource: "profile 'staging'",
+		wantToken:  "staging-token",
+	}, {
+		name:    "unknown profile",
+		profile: "nope",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CC_PROFILE", tt.env)
+
+			c := client.New(client.WithProfile(tt.profile))
+
+			if c.CredentialSource() != tt.wantSource {
+				t.Errorf("expect credentials from %q, got %q", tt.wantSource, c.CredentialSource())
+			}
+
+			token := ""
+			switch auth := c.Authenticator().(type) {
+			case *client.OAuth1Config:
+				token = auth.AccessToken
+			case *client.BearerConfig:
+				token = auth.Token
+			}
+
+			if token != tt.wantToken {
+				t.Errorf("expect token %q, got %q", tt.wantToken, token)
+			}
+		})
+	}
+}
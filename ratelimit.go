@@ -0,0 +1,115 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit is the API rate limiting state, as advertised by response headers.
+type RateLimit struct {
+	// Maximum number of requests allowed in the current window, -1 if unknown
+	Limit int
+	// Number of requests left in the current window, -1 if unknown
+	Remaining int
+	// When the current window ends, zero if unknown
+	Reset time.Time
+	// How long to wait before sending a new request, from Retry-After header
+	RetryAfter time.Duration
+}
+
+// Exhausted tells if no more requests are allowed in the current window.
+func (rl *RateLimit) Exhausted() bool {
+	return rl != nil && (rl.Remaining == 0 || rl.RetryAfter > 0)
+}
+
+// parseRateLimit read Retry-After and X-RateLimit-* headers.
+// Returns nil if none of them are set.
+func parseRateLimit(header http.Header, now time.Time) *RateLimit {
+	if header == nil {
+		return nil
+	}
+
+	rl := &RateLimit{Limit: -1, Remaining: -1}
+	found := false
+
+	if limit, err := strconv.Atoi(strings.TrimSpace(header.Get("X-RateLimit-Limit"))); err == nil {
+		rl.Limit = limit
+		found = true
+	}
+
+	if remaining, err := strconv.Atoi(strings.TrimSpace(header.Get("X-RateLimit-Remaining"))); err == nil {
+		rl.Remaining = remaining
+		found = true
+	}
+
+	if reset, ok := parseRateLimitReset(header.Get("X-RateLimit-Reset"), now); ok {
+		rl.Reset = reset
+		found = true
+	}
+
+	if retryAfter, ok := parseRetryAfter(header.Get("Retry-After"), now); ok {
+		rl.RetryAfter = retryAfter
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+
+	return rl
+}
+
+// parseRetryAfter support both delay-seconds and HTTP-date forms
+// https://www.rfc-editor.org/rfc/rfc9110#field.retry-after
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+
+	return 0, true
+}
+
+// unix timestamps are way bigger than any sane window duration.
+const rateLimitResetEpochThreshold = 1_000_000_000
+
+// parseRateLimitReset support unix timestamps, delay-seconds and HTTP-date forms.
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if n >= rateLimitResetEpochThreshold {
+			return time.Unix(n, 0), true
+		}
+
+		return now.Add(time.Duration(n) * time.Second), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return date, true
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,106 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_response_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   *client.RateLimit
+	}{{
+		name: "no headers",
+		want: nil,
+	}, {
+		name: "x-ratelimit headers",
+		header: map[string]string{
+			"X-RateLimit-Limit":     "100",
+			"X-RateLimit-Remaining": "42",
+			"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+		},
+		want: &client.RateLimit{Limit: 100, Remaining: 42, Reset: reset},
+	}, {
+		name: "retry-after seconds",
+		header: map[string]string{
+			"Retry-After": "30",
+		},
+		want: &client.RateLimit{Limit: -1, Remaining: -1, RetryAfter: 30 * time.Second},
+	}}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+			}))
+			defer srv.Close()
+
+			res := client.Get[client.Nothing](context.Background(), client.New(client.WithEndpoint(srv.URL)), "/")
+			got := res.RateLimit()
+
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("expect no rate limit, got %+v", got)
+			case tt.want == nil:
+			case got == nil:
+				t.Errorf("expect rate limit %+v, got nil", tt.want)
+			case got.Limit != tt.want.Limit || got.Remaining != tt.want.Remaining ||
+				!got.Reset.Equal(tt.want.Reset) || got.RetryAfter != tt.want.RetryAfter:
+				t.Errorf("expect rate limit %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_client_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", time.Now().Add(-time.Second).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithRetryPolicy(client.RetryPolicy{
+			MaxAttempts:     2,
+			MinBackoff:      time.Hour,
+			MaxBackoff:      time.Hour,
+			HonorRetryAfter: true,
+		}),
+	)
+
+	// POST are retried on 429 as the server did not handle the request
+	res := client.Post[client.Nothing](context.Background(), c, "/", nil)
+	if res.HasError() {
+		t.Errorf("expect no error, got: %v", res.Error())
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expect 2 attempts, got %d", got)
+	}
+}
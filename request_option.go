@@ -0,0 +1,130 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RequestOption customize a single request.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	query   url.Values
+	header  http.Header
+	timeout time.Duration
+	bridge  bool
+
+	reconnect   bool
+	onReconnect func(ReconnectEvent)
+	decoders    map[string]EventDecoder
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{
+		query:  url.Values{},
+		header: http.Header{},
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// Add query parameters to the request URL, merged with the ones already in the path.
+func WithQuery(query url.Values) RequestOption {
+	return func(o *requestOptions) {
+		for key, values := range query {
+			for _, value := range values {
+				o.query.Add(key, value)
+			}
+		}
+	}
+}
+
+// Set a request header.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Set(key, value)
+	}
+}
+
+// Bound the request duration, including retries.
+// On streams, the timeout bounds the whole stream life.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// Set an Idempotency-Key header, POST requests carrying one can be retried safely.
+func WithIdempotencyKey(key string) RequestOption {
+	return WithHeader("Idempotency-Key", key)
+}
+
+// Reopen a stream when its connection ends, with the Last-Event-ID header set.
+// The server reconnection time is waited, or a backoff following the client retry policy.
+// The stream fails after MaxAttempts consecutive failed reconnections of the retry policy,
+// or at once if the request cannot be signed, the server answers with a client error,
+// or with something else than an event stream. A 204 answer ends the stream.
+// onReconnect, if not nil, is called before each attempt.
+func WithReconnect(onReconnect func(ReconnectEvent)) RequestOption {
+	return func(o *requestOptions) {
+		o.reconnect = true
+		o.onReconnect = onReconnect
+	}
+}
+
+// Decode data of stream events with the given name using decode, see StreamEvent.Decoded().
+//
+//	client.WithEventDecoder("heartbeat", client.DecodeJSON[Heartbeat])
+func WithEventDecoder(event string, decode EventDecoder) RequestOption {
+	return func(o *requestOptions) {
+		if o.decoders == nil {
+			o.decoders = map[string]EventDecoder{}
+		}
+
+		o.decoders[event] = decode
+	}
+}
+
+// Send the request to the bridge endpoint instead of the main one.
+func onBridge() RequestOption {
+	return func(o *requestOptions) {
+		o.bridge = true
+	}
+}
+
+// url build the request URL from client endpoint, path and query options.
+func (o *requestOptions) url(endpoint, path string) (string, error) {
+	u, err := url.Parse(endpoint + path)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid request URL")
+	}
+
+	if len(o.query) == 0 {
+		return u.String(), nil
+	}
+
+	query := u.Query()
+
+	for key, values := range o.query {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+func (o *requestOptions) apply(req *http.Request) {
+	for key, values := range o.header {
+		req.Header[key] = append([]string{}, values...)
+	}
+}
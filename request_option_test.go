@@ -0,0 +1,76 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_client_RequestOptions(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query(); got.Get("limit") != "10" || got.Get("offset") != "20" {
+			t.Errorf("expect query to be merged, got %s", r.URL.RawQuery)
+		}
+
+		if got := r.Header.Get("Accept"); got != "application/x-ndjson" {
+			t.Errorf("expect Accept header, got %q", got)
+		}
+
+		if got := r.Header.Get("Idempotency-Key"); got != "key-1" {
+			t.Errorf("expect Idempotency-Key header, got %q", got)
+		}
+
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}),
+	)
+
+	res := client.Post[client.Nothing](context.Background(), c, "/v2/items?limit=10", nil,
+		client.WithQuery(url.Values{"offset": []string{"20"}}),
+		client.WithHeader("Accept", "application/x-ndjson"),
+		client.WithIdempotencyKey("key-1"),
+	)
+	if res.HasError() {
+		t.Fatalf("unexpected error: %v", res.Error())
+	}
+
+	// POST with an idempotency key are retried
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expect 2 attempts, got %d", got)
+	}
+}
+
+func Test_client_RequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(client.WithEndpoint(srv.URL))
+
+	res := client.Get[client.Nothing](context.Background(), c, "/", client.WithTimeout(20*time.Millisecond))
+	if !res.HasError() {
+		t.Errorf("expect a timeout error")
+	}
+}
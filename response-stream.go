@@ -2,10 +2,16 @@ package client
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
-	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -21,85 +27,208 @@ type StreamResponse[T any] interface {
 
 	Equal(anotherResponse StreamResponse[T]) bool
 
+	// Close stop the stream and cancel its request, it can be called several times.
+	// Payload() channel is closed once the stream is stopped.
 	Close()
 	Payload() <-chan *StreamEvent[T]
+
+	// Each calls fn for each event, until the stream ends, ctx is done or fn returns an error.
+	// The stream is closed when Each returns, the error of fn, ctx or the stream is returned.
+	Each(ctx context.Context, fn func(ev *StreamEvent[T]) error) error
+
+	// With Go 1.23 or later, Events(ctx) returns an iterator over events, see response-stream_iter.go
+	streamIterator[T]
 }
 
 type streamResponse[T any] struct {
 	*http.Response
-	err      error
-	close    chan struct{}
+
+	mu  sync.Mutex
+	err error
+
+	closeOnce sync.Once
+	closed    chan struct{}
+
 	payloads chan *StreamEvent[T]
+	cancel   context.CancelFunc
+	ctx      context.Context
+	// nil if the stream must not be reopened
+	reconnect *streamReconnect
+	decoders  map[string]EventDecoder
+}
+
+// ReconnectEvent describe a stream reconnection, see WithReconnect().
+type ReconnectEvent struct {
+	// Reconnection attempt since the last established connection, from 1
+	Attempt int
+	// Sent in the Last-Event-ID header, empty if no event had an ID
+	LastEventID string
+	// Wait before reconnecting
+	Delay time.Duration
+	// Why the previous connection ended, nil if the server closed it
+	Err error
+}
+
+// streamReconnect reopens a stream once its connection ended.
+type streamReconnect struct {
+	connect func(lastEventID string) (*http.Response, error)
+	backoff func(attempt int) time.Duration
+	// consecutive failed attempts before giving up
+	maxAttempts int
+	notify      func(ReconnectEvent)
 }
 
 // https://developer.mozilla.org/en-US/docs/Web/API/Server-sent_events/Using_server-sent_events#event_stream_format
 type StreamEvent[T any] struct {
+	// Event type, "message" if not set by the server
 	Event string
-	Data  []byte
-	ID    []byte
+	// Data lines, joined by "\n"
+	Data []byte
+	// Last event ID, it persists across events until the server changes it
+	ID []byte
+	// Reconnection time in milliseconds last sent by the server, 0 if none
 	Retry int64
+
+	decoders map[string]EventDecoder
+}
+
+// EventDecoder decode data of stream events, see WithEventDecoder().
+type EventDecoder func(data []byte) (interface{}, error)
+
+// DecodeJSON is an EventDecoder which JSON-decodes data into a *V.
+func DecodeJSON[V any](data []byte) (interface{}, error) {
+	var value V
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return &value, nil
 }
 
 func (se *StreamEvent[T]) String() string {
 	return fmt.Sprintf("Event=%s\tID=%s\t%s", se.Event, string(se.ID), string(se.Data))
 }
 
+// Value JSON-decodes event data into T.
+func (se *StreamEvent[T]) Value() (*T, error) {
+	var value T
+	if err := json.Unmarshal(se.Data, &value); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse '%s' event data", se.Event)
+	}
+
+	return &value, nil
+}
+
+// Decoded returns event data decoded by the decoder registered for its event name,
+// or a *T, as Value() does, if there is none.
+func (se *StreamEvent[T]) Decoded() (interface{}, error) {
+	decode, ok := se.decoders[se.Event]
+	if !ok {
+		value, err := se.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	}
+
+	value, err := decode(se.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse '%s' event data", se.Event)
+	}
+
+	return value, nil
+}
+
 func fromErrorStream[T any](err error) StreamResponse[T] {
+	payloads := make(chan *StreamEvent[T])
+	close(payloads)
+
 	return &streamResponse[T]{
-		err:   err,
-		close: make(chan struct{}),
+		err:      err,
+		closed:   make(chan struct{}),
+		payloads: payloads,
+		cancel:   func() {},
 	}
 }
 
-func fromHTTPStream[T any](httpRes *http.Response) StreamResponse[T] {
-	defer httpRes.Body.Close()
-
+func fromHTTPStream[T any](ctx context.Context, httpRes *http.Response, cancel context.CancelFunc, reconnect *streamReconnect, decoders map[string]EventDecoder) StreamResponse[T] {
 	res := &streamResponse[T]{
-		Response: httpRes,
-		close:    make(chan struct{}, 2),
-		payloads: make(chan *StreamEvent[T], 10),
+		Response:  httpRes,
+		closed:    make(chan struct{}),
+		payloads:  make(chan *StreamEvent[T], 10),
+		cancel:    cancel,
+		ctx:       ctx,
+		reconnect: reconnect,
+		decoders:  decoders,
 	}
 
-	if httpRes.StatusCode >= 300 {
-		res.err = errors.Errorf("invalid response from CleverCloud API (status=%d)", httpRes.StatusCode)
+	if httpRes.StatusCode == http.StatusNoContent {
+		// the server asks not to connect, nor to reconnect
+		httpRes.Body.Close()
+		cancel()
+		close(res.payloads)
 
 		return res
 	}
 
-	stream := bufio.NewScanner(res.Body)
-	stream.Split(spliter)
+	if httpRes.StatusCode >= 300 {
+		defer httpRes.Body.Close()
+
+		cancel()
+
+		body, _ := io.ReadAll(httpRes.Body)
+		res.err = newAPIError(httpRes, body)
 
-	go res.loop(stream)
+		close(res.payloads)
+
+		return res
+	}
+
+	go res.loop(httpRes.Body)
 
 	return res
 }
 
+// response returns the HTTP response of the current connection.
+func (r *streamResponse[T]) response() *http.Response {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.Response
+}
+
 func (r *streamResponse[T]) StatusCode() int {
-	if r.Response == nil {
+	res := r.response()
+	if res == nil {
 		return 0
 	}
 
-	return r.Response.StatusCode
+	return res.StatusCode
 }
 
 func (r *streamResponse[T]) SozuID() string {
-	if r.Response == nil {
+	res := r.response()
+	if res == nil {
 		return ""
 	}
 
-	return r.Response.Header.Get("Sozu-Id")
+	return res.Header.Get("Sozu-Id")
 }
 
 func (r *streamResponse[T]) Error() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	return r.err
 }
 
 func (r *streamResponse[T]) HasError() bool {
-	return r.err != nil
+	return r.Error() != nil
 }
 
 func (r *streamResponse[T]) IsNotFoundError() bool {
-	return r.Response.StatusCode == http.StatusNotFound
+	return r.StatusCode() == http.StatusNotFound
 }
 
 func (r *streamResponse[T]) Equal(anotherResponse StreamResponse[T]) bool {
@@ -112,82 +241,407 @@ func (r *streamResponse[T]) Payload() <-chan *StreamEvent[T] {
 }
 
 func (r *streamResponse[T]) Close() {
-	// non-blocking chan write
+	r.closeOnce.Do(func() {
+		close(r.closed)
+		// abort a pending read or connection
+		r.cancel()
+	})
+}
+
+func (r *streamResponse[T]) Each(ctx context.Context, fn func(ev *StreamEvent[T]) error) error {
+	defer r.Close()
+
+	ctx = mustContext(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-r.payloads:
+			if !ok {
+				return r.Error()
+			}
+
+			if err := fn(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (r *streamResponse[T]) isClosed() bool {
 	select {
-	case r.close <- struct{}{}:
+	case <-r.closed:
+		return true
 	default:
+		return false
 	}
 }
 
-func (r *streamResponse[T]) loop(scan *bufio.Scanner) {
+// loop reads events from the body, and reopens the stream when it ends if reconnection is enabled.
+func (r *streamResponse[T]) loop(body io.ReadCloser) {
 	defer func() {
 		close(r.payloads)
+		r.cancel()
 	}()
 
-	raws := make(chan string)
-	errs := make(chan error)
+	parser := newEventStreamParser[T](body)
+
+	for {
+		err := r.read(parser)
+		body.Close()
+
+		// a closed stream ends without error, even if the request was cancelled meanwhile
+		if r.isClosed() {
+			return
+		}
+
+		if r.ctx.Err() != nil {
+			r.fail(r.ctx.Err())
+
+			return
+		}
+
+		if r.reconnect == nil {
+			r.fail(err)
+
+			return
+		}
+
+		body, err = r.reopen(parser, err)
+		if body == nil {
+			if !r.isClosed() {
+				r.fail(err)
+			}
+
+			return
+		}
+
+		parser.reset(body)
+	}
+}
+
+// fail records why the stream stopped, a nil error is a clean end.
+func (r *streamResponse[T]) fail(err error) {
+	if err == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.err = err
+}
+
+// read forwards events until the connection ends, returns nil on EOF.
+// The reading goroutine stops once the body is closed.
+func (r *streamResponse[T]) read(parser *eventStreamParser[T]) error {
+	events := make(chan *StreamEvent[T])
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+
+	defer close(done)
 
 	go func() {
-		for scan.Scan() {
-			if err := scan.Err(); err != nil {
-				errs <- err
+		defer close(events)
+
+		for {
+			ev, err := parser.next()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					errs <- err
+				}
 
-				break
+				return
 			}
 
-			raws <- scan.Text()
+			select {
+			case events <- ev:
+			case <-done:
+				return
+			}
 		}
-		close(raws)
-		close(errs)
 	}()
 
 	for {
 		select {
-		case <-r.close:
-			return
-		case er := <-errs:
-			r.err = er
+		case <-r.closed:
+			return nil
+		case <-r.ctx.Done():
+			return r.ctx.Err()
+		case ev, ok := <-events:
+			if !ok {
+				select {
+				case err := <-errs:
+					return err
+				default:
+					return nil
+				}
+			}
 
-			return
-		case <-r.Request.Context().Done():
-			r.err = r.Request.Context().Err()
+			ev.decoders = r.decoders
 
-			return
-		case raw := <-raws:
-			r.payloads <- rawToStreamEvent[T](raw)
+			select {
+			case r.payloads <- ev:
+			case <-r.closed:
+				return nil
+			case <-r.ctx.Done():
+				return r.ctx.Err()
+			}
+		}
+	}
+}
+
+// reopen waits and reconnects until a connection is established, the stream is closed,
+// or the server answers it must not be reopened.
+// Returns a nil body when the stream must stop, with the error to report if any.
+func (r *streamResponse[T]) reopen(parser *eventStreamParser[T], cause error) (io.ReadCloser, error) {
+	for attempt := 1; ; attempt++ {
+		delay := r.reconnect.backoff(attempt)
+		if retry := time.Duration(parser.retry) * time.Millisecond; retry > delay || (attempt == 1 && retry > 0) {
+			delay = retry
+		}
+
+		if r.reconnect.notify != nil {
+			r.reconnect.notify(ReconnectEvent{
+				Attempt:     attempt,
+				LastEventID: parser.lastEventID,
+				Delay:       delay,
+				Err:         cause,
+			})
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-r.closed:
+			timer.Stop()
+
+			return nil, nil
+		case <-r.ctx.Done():
+			timer.Stop()
+
+			return nil, r.ctx.Err()
+		case <-timer.C:
+		}
+
+		res, err := r.reconnect.connect(parser.lastEventID)
+		if err != nil {
+			if r.ctx.Err() != nil {
+				return nil, r.ctx.Err()
+			}
+
+			// a request which cannot be signed will not get better
+			var signErr *signError
+			if errors.As(err, &signErr) {
+				return nil, err
+			}
+
+			cause = err
+		} else {
+			switch {
+			case res.StatusCode == http.StatusNoContent:
+				// the server asks not to reconnect
+				res.Body.Close()
+
+				return nil, nil
+			case res.StatusCode >= 200 && res.StatusCode < 300:
+				if !isEventStream(res) {
+					res.Body.Close()
+
+					return nil, errors.Errorf("cannot reconnect stream, unexpected content type '%s'", res.Header.Get("Content-Type"))
+				}
+
+				r.mu.Lock()
+				r.Response = res
+				r.mu.Unlock()
+
+				return res.Body, nil
+			default:
+				body, _ := io.ReadAll(res.Body)
+				res.Body.Close()
+
+				cause = newAPIError(res, body)
+
+				if res.StatusCode != http.StatusTooManyRequests && res.StatusCode < 500 {
+					return nil, cause
+				}
+			}
+		}
+
+		if attempt >= r.reconnect.maxAttempts {
+			return nil, errors.Wrapf(cause, "cannot reconnect stream after %d attempts", attempt)
 		}
 	}
 }
 
-func rawToStreamEvent[T any](raw string) *StreamEvent[T] {
-	ev := &StreamEvent[T]{Data: make([]byte, 0)}
+// isEventStream tells if a response is an event stream, other answers must not be read as one.
+func isEventStream(res *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(res.Header.Get("Content-Type"))
+
+	return err == nil && mediaType == "text/event-stream"
+}
+
+// eventStreamParser implements the WHATWG event stream interpretation algorithm.
+// https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+type eventStreamParser[T any] struct {
+	r       *bufio.Reader
+	started bool
+	// last line ended with CR, a following LF belongs to the same line ending
+	skipLF bool
+	line   []byte
+
+	data        bytes.Buffer
+	eventType   string
+	idBuffer    string
+	lastEventID string
+	retry       int64
+}
+
+func newEventStreamParser[T any](r io.Reader) *eventStreamParser[T] {
+	return &eventStreamParser[T]{r: bufio.NewReader(r)}
+}
+
+// reset read a new connection, last event ID and reconnection time are kept.
+func (p *eventStreamParser[T]) reset(r io.Reader) {
+	p.r = bufio.NewReader(r)
+	p.started = false
+	p.skipLF = false
+	p.data.Reset()
+	p.eventType = ""
+	p.idBuffer = p.lastEventID
+}
 
-	for _, split := range strings.Split(raw, "\n") {
-		switch {
-		case strings.HasPrefix(split, ":"):
+// next returns the next dispatched event.
+// At the end of the stream, an incomplete event is discarded and io.EOF is returned.
+func (p *eventStreamParser[T]) next() (*StreamEvent[T], error) {
+	if !p.started {
+		p.started = true
+
+		if bom, _ := p.r.Peek(3); bytes.Equal(bom, []byte("\xEF\xBB\xBF")) {
+			_, _ = p.r.Discard(3)
+		}
+	}
+
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(line) == 0 {
+			if ev := p.dispatch(); ev != nil {
+				return ev, nil
+			}
+
+			continue
+		}
+
+		if line[0] == ':' {
 			continue
-		case strings.HasPrefix(split, "id:"):
-			ev.ID = []byte(strings.TrimPrefix(split, "id:"))
-		case strings.HasPrefix(split, "event:"):
-			ev.Event = strings.TrimPrefix(split, "event:")
-		case strings.HasPrefix(split, "retry:"):
-			n, _ := strconv.ParseInt(strings.TrimPrefix(split, "retry:"), 10, 64)
-			ev.Retry = n
-		case strings.HasPrefix(split, "data:"):
-			ev.Data = append(ev.Data, []byte(strings.TrimPrefix(split, "data:"))...)
 		}
+
+		// the stream is decoded as UTF-8, invalid sequences are replaced
+		line = bytes.ToValidUTF8(line, []byte("\uFFFD"))
+
+		field, value := line, []byte{}
+		if i := bytes.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], line[i+1:]
+			value = bytes.TrimPrefix(value, []byte(" "))
+		}
+
+		p.process(string(field), value)
 	}
+}
 
-	return ev
+// readLine returns the next line, without its CRLF, LF or CR ending.
+// A line without ending at the end of the stream is dropped.
+func (p *eventStreamParser[T]) readLine() ([]byte, error) {
+	p.line = p.line[:0]
+
+	for {
+		b, err := p.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.skipLF {
+			p.skipLF = false
+
+			if b == '\n' {
+				continue
+			}
+		}
+
+		switch b {
+		case '\r':
+			p.skipLF = true
+
+			return p.line, nil
+		case '\n':
+			return p.line, nil
+		}
+
+		p.line = append(p.line, b)
+	}
 }
 
-func spliter(data []byte, atEOF bool) (int, []byte, error) {
-	size := len(data)
+func (p *eventStreamParser[T]) process(field string, value []byte) {
+	switch field {
+	case "event":
+		p.eventType = string(value)
+	case "data":
+		p.data.Write(value)
+		p.data.WriteByte('\n')
+	case "id":
+		if bytes.IndexByte(value, 0) < 0 {
+			p.idBuffer = string(value)
+		}
+	case "retry":
+		if isASCIIDigits(value) {
+			if retry, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+				p.retry = retry
+			}
+		}
+	}
+}
 
-	data = []byte(strings.TrimSuffix(string(data), "\n\n"))
+func (p *eventStreamParser[T]) dispatch() *StreamEvent[T] {
+	p.lastEventID = p.idBuffer
 
-	if atEOF {
-		return size, data, bufio.ErrFinalToken
+	defer func() {
+		p.data.Reset()
+		p.eventType = ""
+	}()
+
+	if p.data.Len() == 0 {
+		return nil
 	}
-	return size, data, nil
+
+	ev := &StreamEvent[T]{
+		Event: p.eventType,
+		Data:  bytes.TrimSuffix(append([]byte{}, p.data.Bytes()...), []byte("\n")),
+		ID:    []byte(p.lastEventID),
+		Retry: p.retry,
+	}
+
+	if ev.Event == "" {
+		ev.Event = "message"
+	}
+
+	return ev
+}
+
+func isASCIIDigits(value []byte) bool {
+	if len(value) == 0 {
+		return false
+	}
+
+	for _, b := range value {
+		if b < '0' || b > '9' {
+			return false
+		}
+	}
+
+	return true
 }
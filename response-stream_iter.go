@@ -0,0 +1,46 @@
+//go:build go1.23
+
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/pkg/errors"
+)
+
+var errStopIteration = errors.New("stop iteration")
+
+type streamIterator[T any] interface {
+	// Events returns an iterator over events of the stream:
+	//
+	//	for ev, err := range res.Events(ctx) {
+	//		if err != nil {
+	//			// the stream failed
+	//		}
+	//	}
+	//
+	// Breaking the loop closes the stream. If the stream fails, a last nil event is yielded with the error.
+	Events(ctx context.Context) iter.Seq2[*StreamEvent[T], error]
+}
+
+func (r *streamResponse[T]) Events(ctx context.Context) iter.Seq2[*StreamEvent[T], error] {
+	return func(yield func(*StreamEvent[T], error) bool) {
+		err := r.Each(ctx, func(ev *StreamEvent[T]) error {
+			if !yield(ev, nil) {
+				return errStopIteration
+			}
+
+			return nil
+		})
+
+		if err != nil && !errors.Is(err, errStopIteration) {
+			yield(nil, err)
+		}
+	}
+}
+
+// Events returns an iterator over events of the stream, see StreamResponse.Events().
+func Events[T any](ctx context.Context, res StreamResponse[T]) iter.Seq2[*StreamEvent[T], error] {
+	return res.Events(ctx)
+}
@@ -0,0 +1,65 @@
+//go:build go1.23
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_Events(t *testing.T) {
+	checkGoroutines(t)
+
+	c := streamServer(t, endless)
+	res := client.Stream[client.Nothing](context.Background(), c, "/events")
+
+	count := 0
+
+	for ev, err := range res.Events(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if ev == nil {
+			t.Fatal("expect an event")
+		}
+
+		count++
+		if count == 3 {
+			break
+		}
+	}
+
+	// breaking the loop closed the stream
+	for range res.Payload() {
+	}
+
+	if res.Error() != nil {
+		t.Errorf("expect a clean end, got %v", res.Error())
+	}
+}
+
+func Test_Events_error(t *testing.T) {
+	checkGoroutines(t)
+
+	c := streamServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	errs := []error{}
+
+	for ev, err := range client.Events(context.Background(), client.Stream[client.Nothing](context.Background(), c, "/events")) {
+		if ev != nil {
+			t.Errorf("expect no event, got %s", ev)
+		}
+
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 1 || !client.IsForbidden(errs[0]) {
+		t.Errorf("expect the stream error to be yielded once, got %v", errs)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !go1.23
+
+package client
+
+// streamIterator adds Events() to StreamResponse with Go 1.23 or later.
+type streamIterator[T any] interface{}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+type sseEvent struct {
+	Event string
+	Data  string
+	ID    string
+	Retry int64
+}
+
+func parseEventStream(t *testing.T, r io.Reader) []sseEvent {
+	t.Helper()
+
+	parser := newEventStreamParser[Nothing](r)
+	events := []sseEvent{}
+
+	for {
+		ev, err := parser.next()
+		if err == io.EOF {
+			return events
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		events = append(events, sseEvent{Event: ev.Event, Data: string(ev.Data), ID: string(ev.ID), Retry: ev.Retry})
+	}
+}
+
+func Test_eventStreamParser(t *testing.T) {
+	t.Parallel()
+
+	bigData := strings.Repeat("x", 200*1024)
+
+	tests := []struct {
+		name   string
+		stream string
+		events []sseEvent
+	}{{
+		name:   "data lines are joined with LF",
+		stream: "data: YHOO\ndata: +2\ndata: 10\n\n",
+		events: []sseEvent{{Event: "message", Data: "YHOO\n+2\n10"}},
+	}, {
+		name:   "comments, ids and leading space",
+		stream: ": test stream\n\ndata: first event\nid: 1\n\ndata:second event\nid\n\ndata:  third event\n\n",
+		events: []sseEvent{
+			{Event: "message", Data: "first event", ID: "1"},
+			{Event: "message", Data: "second event"},
+			{Event: "message", Data: " third event"},
+		},
+	}, {
+		name:   "empty data and trailing incomplete event",
+		stream: "data\n\ndata\ndata\n\ndata:",
+		events: []sseEvent{{Event: "message", Data: ""}, {Event: "message", Data: "\n"}},
+	}, {
+		name:   "space after colon is optional",
+		stream: "data:test\n\ndata: test\n\n",
+		events: []sseEvent{{Event: "message", Data: "test"}, {Event: "message", Data: "test"}},
+	}, {
+		name:   "CRLF line endings",
+		stream: "data: a\r\ndata: b\r\n\r\n",
+		events: []sseEvent{{Event: "message", Data: "a\nb"}},
+	}, {
+		name:   "CR line endings",
+		stream: "data: a\rdata: b\r\rdata: c\r\r",
+		events: []sseEvent{{Event: "message", Data: "a\nb"}, {Event: "message", Data: "c"}},
+	}, {
+		name:   "mixed line endings",
+		stream: "data: a\r\ndata: b\rdata: c\n\r\n",
+		events: []sseEvent{{Event: "message", Data: "a\nb\nc"}},
+	}, {
+		name:   "CR CR is a blank line, not a CRLF",
+		stream: "data: a\r\r\ndata: b\n\n",
+		events: []sseEvent{{Event: "message", Data: "a"}, {Event: "message", Data: "b"}},
+	}, {
+		name:   "leading BOM is stripped once",
+		stream: "\xEF\xBB\xBFdata: a\n\n\xEF\xBB\xBFdata: b\n\n",
+		events: []sseEvent{{Event: "message", Data: "a"}},
+	}, {
+		name:   "event type is reset after dispatch",
+		stream: "event: add\ndata: 1\n\ndata: 2\n\n",
+		events: []sseEvent{{Event: "add", Data: "1"}, {Event: "message", Data: "2"}},
+	}, {
+		name:   "event type without data is not dispatched",
+		stream: "event: add\n\ndata: 1\n\n",
+		events: []sseEvent{{Event: "message", Data: "1"}},
+	}, {
+		name:   "last event ID persists across events",
+		stream: "id: 1\ndata: a\n\ndata: b\n\nid\ndata: c\n\n",
+		events: []sseEvent{
+			{Event: "message", Data: "a", ID: "1"},
+			{Event: "message", Data: "b", ID: "1"},
+			{Event: "message", Data: "c"},
+		},
+	}, {
+		name:   "ID without data is kept for next events",
+		stream: "id: 7\n\ndata: a\n\n",
+		events: []sseEvent{{Event: "message", Data: "a", ID: "7"}},
+	}, {
+		name:   "ID containing NULL is ignored",
+		stream: "id: 1\ndata: a\n\nid: 2\x003\ndata: b\n\n",
+		events: []sseEvent{{Event: "message", Data: "a", ID: "1"}, {Event: "message", Data: "b", ID: "1"}},
+	}, {
+		name:   "retry must be ASCII digits",
+		stream: "retry: 1000\ndata: a\n\nretry: 10a\ndata: b\n\nretry:  200\ndata: c\n\nretry\ndata: d\n\nretry: 3000\n\ndata: e\n\n",
+		events: []sseEvent{
+			{Event: "message", Data: "a", Retry: 1000},
+			{Event: "message", Data: "b", Retry: 1000},
+			{Event: "message", Data: "c", Retry: 1000},
+			{Event: "message", Data: "d", Retry: 1000},
+			{Event: "message", Data: "e", Retry: 3000},
+		},
+	}, {
+		name:   "unknown fields are ignored",
+		stream: "foo: bar\ndata: a\nDATA: b\n\n",
+		events: []sseEvent{{Event: "message", Data: "a"}},
+	}, {
+		name:   "only the first colon separates field and value",
+		stream: "data: a: b\n\n",
+		events: []sseEvent{{Event: "message", Data: "a: b"}},
+	}, {
+		name:   "invalid UTF-8 is replaced",
+		stream: "data: a\xffb\n\n",
+		events: []sseEvent{{Event: "message", Data: "a�b"}},
+	}, {
+		name:   "events bigger than read buffers",
+		stream: "data: " + bigData + "\n\n",
+		events: []sseEvent{{Event: "message", Data: bigData}},
+	}, {
+		name:   "empty stream",
+		stream: "",
+		events: []sseEvent{},
+	}}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if events := parseEventStream(t, strings.NewReader(test.stream)); !reflect.DeepEqual(events, test.events) {
+				t.Errorf("expect %+v, got %+v", test.events, events)
+			}
+
+			// CRLF and BOM split across reads
+			if events := parseEventStream(t, iotest.OneByteReader(strings.NewReader(test.stream))); !reflect.DeepEqual(events, test.events) {
+				t.Errorf("byte by byte: expect %+v, got %+v", test.events, events)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -16,6 +17,7 @@ type Response[T any] interface {
 	IsNotFoundError() bool
 
 	SozuID() string
+	RateLimit() *RateLimit
 
 	Equal(anotherResponse Response[T]) bool
 
@@ -24,20 +26,23 @@ type Response[T any] interface {
 
 type response[T any] struct {
 	*http.Response
-	rawBody []byte
-	err     error
-	payload T
+	rawBody   []byte
+	err       error
+	payload   T
+	rateLimit *RateLimit
 }
 
 func fromHTTPResponse[T any](httpRes *http.Response) Response[T] {
-	res := &response[T]{Response: httpRes}
+	res := &response[T]{
+		Response:  httpRes,
+		rateLimit: parseRateLimit(httpRes.Header, time.Now()),
+	}
 
 	var readBodyErr error
 	res.rawBody, readBodyErr = io.ReadAll(res.Body)
 
 	if httpRes.StatusCode >= 300 {
-		err := errors.New(string(res.rawBody))
-		res.err = errors.Wrapf(err, "invalid response from CleverCloud API (status=%d)", httpRes.StatusCode)
+		res.err = newAPIError(httpRes, res.rawBody)
 
 		return res
 	}
@@ -83,6 +88,11 @@ func (r *response[T]) SozuID() string {
 	return r.Response.Header.Get("Sozu-Id")
 }
 
+// RateLimit returns the rate limiting state advertised by the API, nil if unknown.
+func (r *response[T]) RateLimit() *RateLimit {
+	return r.rateLimit
+}
+
 func (r *response[T]) Error() error {
 	return r.err
 }
@@ -92,7 +102,7 @@ func (r *response[T]) HasError() bool {
 }
 
 func (r *response[T]) IsNotFoundError() bool {
-	return r.Response.StatusCode == http.StatusNotFound
+	return r.StatusCode() == http.StatusNotFound
 }
 
 func (r *response[T]) Equal(anotherResponse Response[T]) bool {
@@ -103,3 +113,30 @@ func (r *response[T]) Equal(anotherResponse Response[T]) bool {
 func (r *response[T]) Payload() *T {
 	return &r.payload
 }
+
+// Map derive a response with another payload, keeping status and headers of the original one.
+// transform is only called on successful responses.
+func Map[T, U any](res Response[T], transform func(payload *T) (U, error)) Response[U] {
+	mapped := &response[U]{err: res.Error()}
+
+	if r, ok := res.(*response[T]); ok {
+		mapped.Response = r.Response
+		mapped.rawBody = r.rawBody
+		mapped.rateLimit = r.rateLimit
+	}
+
+	if mapped.err != nil {
+		return mapped
+	}
+
+	payload, err := transform(res.Payload())
+	if err != nil {
+		mapped.err = err
+
+		return mapped
+	}
+
+	mapped.payload = payload
+
+	return mapped
+}
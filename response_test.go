@@ -0,0 +1,48 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"go.clever-cloud.dev/client"
+)
+
+func Test_Map(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Sozu-Id", "sozu")
+
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`["a","b"]`))
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+	count := func(payload *[]string) (int, error) { return len(*payload), nil }
+
+	res := client.Map(client.Get[[]string](context.Background(), c, "/"), count)
+	if res.HasError() || *res.Payload() != 2 || res.SozuID() != "sozu" {
+		t.Errorf("unexpected mapped response: %d, %s (%v)", *res.Payload(), res.SozuID(), res.Error())
+	}
+
+	missing := client.Map(client.Get[[]string](context.Background(), c, "/missing"), count)
+	if !missing.IsNotFoundError() || !client.IsNotFound(missing.Error()) {
+		t.Errorf("expect original error to be kept, got %v", missing.Error())
+	}
+
+	failed := client.Map(client.Get[[]string](context.Background(), c, "/"), func(payload *[]string) (int, error) {
+		return 0, errors.New("boom")
+	})
+	if failed.Error() == nil || failed.StatusCode() != http.StatusOK {
+		t.Errorf("expect transform error, got %v", failed.Error())
+	}
+}
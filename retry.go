@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy describe how failed requests are retried.
+type RetryPolicy struct {
+	// Maximum number of attempts for a single request, including the first one
+	MaxAttempts int
+	// Delay before the first retry, doubled on each attempt
+	MinBackoff time.Duration
+	// Upper bound of the delay between two attempts
+	MaxBackoff time.Duration
+	// Also retry POST requests, which are not idempotent, POST requests with an Idempotency-Key are always retried
+	RetryPOST bool
+	// Wait for the delay given by the Retry-After header on 429 and 503 responses
+	// instead of the computed backoff, 429 responses are then retried whatever the method is
+	HonorRetryAfter bool
+	// Do not retry if the server asks to wait longer than this, default: no limit
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns a sensible policy: 3 attempts, from 200ms to 5s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  200 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+	}
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	def := DefaultRetryPolicy()
+
+	if p.MaxAttempts < 1 {
+		p.MaxAttempts = 1
+	}
+
+	if p.MinBackoff <= 0 {
+		p.MinBackoff = def.MinBackoff
+	}
+
+	if p.MaxBackoff < p.MinBackoff {
+		p.MaxBackoff = p.MinBackoff
+	}
+
+	return p
+}
+
+func (p *RetryPolicy) allowRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return p.RetryPOST || req.Header.Get("Idempotency-Key") != ""
+	default:
+		return false
+	}
+}
+
+// shouldRetry tells if an attempt which ended with res or err must be retried.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, req *http.Request, attempt int, res *http.Response, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	if !p.allowRequest(req) && !(p.HonorRetryAfter && res != nil && res.StatusCode == http.StatusTooManyRequests) {
+		return false
+	}
+
+	if err != nil {
+		// network error, a request which cannot be signed will not get better
+		var signErr *signError
+
+		return !errors.As(err, &signErr)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// delay compute how long to wait after the given attempt.
+// Returns false if the server asks to wait longer than allowed.
+func (p *RetryPolicy) delay(attempt int, res *http.Response) (time.Duration, bool) {
+	if p.HonorRetryAfter && res != nil &&
+		(res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable) {
+		if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			if p.MaxRetryAfter > 0 && retryAfter > p.MaxRetryAfter {
+				return retryAfter, false
+			}
+
+			return retryAfter, true
+		}
+	}
+
+	return p.backoff(attempt), true
+}
+
+// backoff compute the delay to wait after the given attempt.
+// Use an exponential backoff with "equal jitter": half of the delay is fixed, the other half is random.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MaxBackoff
+	if shift := attempt - 1; shift < 32 {
+		if d := p.MinBackoff << shift; d > 0 && d < p.MaxBackoff {
+			delay = d
+		}
+	}
+
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+
+	jitter, err := rand.Int(rand.Reader, big.NewInt(half))
+	if err != nil {
+		return delay
+	}
+
+	return time.Duration(half + jitter.Int64())
+}
+
+// do send the request built by newRequest, retrying it according to client retry policy.
+// newRequest is called for each attempt so the body is re-sent and the request signed again.
+// On a 401 response, credentials are refreshed and the request sent once more.
+func (c *Client) do(ctx context.Context, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	refreshed := false
+
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.doer.Do(req)
+
+		if err == nil && res.StatusCode == http.StatusUnauthorized && !refreshed && c.Authenticator() != nil {
+			refreshed = true
+
+			if rerr := c.refreshCredentials(ctx); rerr != nil {
+				c.log.WithError(rerr).Debugf("RETRY:\t%s\t%s\t->\tcannot refresh credentials", req.Method, req.URL.String())
+			} else {
+				c.log.Infof("RETRY:\t%s\t%s\t->\tcredentials refreshed, retrying", req.Method, req.URL.String())
+				closeResponse(res)
+				attempt--
+
+				continue
+			}
+		}
+
+		if !c.retryPolicy.shouldRetry(ctx, req, attempt, res, err) {
+			return res, err
+		}
+
+		delay, ok := c.retryPolicy.delay(attempt, res)
+		if !ok {
+			c.log.Warnf("RETRY:\t%s\t%s\t->\tserver asks to wait %s, giving up", req.Method, req.URL.String(), delay)
+
+			return res, err
+		}
+
+		c.log.Warnf("RETRY:\t%s\t%s\t->\tattempt %d/%d failed, retrying in %s", req.Method, req.URL.String(), attempt, c.retryPolicy.MaxAttempts, delay)
+
+		if res != nil {
+			closeResponse(res)
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// closeResponse drain body to allow connection reuse.
+func closeResponse(res *http.Response) {
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+}
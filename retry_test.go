@@ -0,0 +1,141 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.clever-cloud.dev/client"
+)
+
+func Test_client_Retry(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		retryPOST    bool
+		failures     int32
+		wantAttempts int32
+		wantErr      bool
+	}{{
+		name:         "idempotent method is retried",
+		method:       http.MethodPut,
+		failures:     2,
+		wantAttempts: 3,
+	}, {
+		name:         "give up after max attempts",
+		method:       http.MethodGet,
+		failures:     5,
+		wantAttempts: 3,
+		wantErr:      true,
+	}, {
+		name:         "POST is not retried by default",
+		method:       http.MethodPost,
+		failures:     1,
+		wantAttempts: 1,
+		wantErr:      true,
+	}, {
+		name:         "POST is retried on opt-in",
+		method:       http.MethodPost,
+		retryPOST:    true,
+		failures:     1,
+		wantAttempts: 2,
+	}}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+
+				// body must be re-sent on each attempt
+				if body, _ := io.ReadAll(r.Body); r.Method != http.MethodGet && string(body) != `{"name":"foo"}` {
+					t.Errorf("attempt %d: unexpected body %q", n, string(body))
+				}
+
+				if n <= tt.failures {
+					w.WriteHeader(http.StatusBadGateway)
+
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"name":"bar"}`))
+			}))
+			defer srv.Close()
+
+			c := client.New(
+				client.WithEndpoint(srv.URL),
+				client.WithRetryPolicy(client.RetryPolicy{
+					MaxAttempts: 3,
+					MinBackoff:  time.Millisecond,
+					MaxBackoff:  5 * time.Millisecond,
+					RetryPOST:   tt.retryPOST,
+				}),
+			)
+
+			var res client.Response[payload]
+
+			switch tt.method {
+			case http.MethodGet:
+				res = client.Get[payload](context.Background(), c, "/")
+			case http.MethodPut:
+				res = client.Put[payload](context.Background(), c, "/", payload{Name: "foo"})
+			case http.MethodPost:
+				res = client.Post[payload](context.Background(), c, "/", payload{Name: "foo"})
+			}
+
+			if res.HasError() != tt.wantErr {
+				t.Errorf("expect error=%t, got: %v", tt.wantErr, res.Error())
+			}
+
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("expect %d attempts, got %d", tt.wantAttempts, got)
+			}
+		})
+	}
+}
+
+func Test_client_RetryContext(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := client.New(
+		client.WithEndpoint(srv.URL),
+		client.WithRetryPolicy(client.RetryPolicy{
+			MaxAttempts: 10,
+			MinBackoff:  time.Hour,
+			MaxBackoff:  time.Hour,
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	res := client.Get[client.Nothing](ctx, c, "/")
+	if !res.HasError() {
+		t.Errorf("expect an error")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("retry did not respect context cancellation (took %s)", elapsed)
+	}
+}
@@ -0,0 +1,91 @@
+package self
+
+// User is the current user profile.
+type User struct {
+	ID             string   `json:"id"`
+	Email          string   `json:"email"`
+	Name           string   `json:"name"`
+	Phone          string   `json:"phone"`
+	Address        string   `json:"address"`
+	City           string   `json:"city"`
+	ZipCode        string   `json:"zipcode"`
+	Country        string   `json:"country"`
+	Avatar         *string  `json:"avatar"`
+	CreationDate   int64    `json:"creationDate"`
+	Lang           *string  `json:"lang"`
+	EmailValidated bool     `json:"emailValidated"`
+	OauthApps      []string `json:"oauthApps"`
+	Admin          bool     `json:"admin"`
+	CanPay         bool     `json:"canPay"`
+	PreferredMFA   *string  `json:"preferredMFA"`
+	HasPassword    bool     `json:"hasPassword"`
+}
+
+// MFAEnabled tells if the user set up a second authentication factor.
+func (u *User) MFAEnabled() bool {
+	return u.PreferredMFA != nil && *u.PreferredMFA != "" && *u.PreferredMFA != "NONE"
+}
+
+// UserUpdate holds profile fields to change, empty ones are left untouched.
+type UserUpdate struct {
+	Name    string `json:"name,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+	Address string `json:"address,omitempty"`
+	City    string `json:"city,omitempty"`
+	ZipCode string `json:"zipcode,omitempty"`
+	Country string `json:"country,omitempty"`
+	Lang    string `json:"lang,omitempty"`
+}
+
+// SSHKey is a public key allowed to push on user applications.
+type SSHKey struct {
+	Name        string `json:"name"`
+	Key         string `json:"key"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// LinkedAccount is a third-party account the user logs in with.
+type LinkedAccount struct {
+	Provider string `json:"provider"`
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// Summary is an overview of everything the user can access.
+type Summary struct {
+	User          SummaryUser           `json:"user"`
+	Organisations []SummaryOrganisation `json:"organisations"`
+}
+
+type SummaryUser struct {
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	Avatar       *string              `json:"avatar"`
+	Applications []SummaryApplication `json:"applications"`
+	Addons       []SummaryAddon       `json:"addons"`
+}
+
+type SummaryOrganisation struct {
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	Avatar       *string              `json:"avatar"`
+	Role         string               `json:"role"`
+	Applications []SummaryApplication `json:"applications"`
+	Addons       []SummaryAddon       `json:"addons"`
+}
+
+type SummaryApplication struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	State        string `json:"state"`
+	CommitID     string `json:"commitId"`
+	InstanceType string `json:"instanceType"`
+	VariantSlug  string `json:"variantSlug"`
+}
+
+type SummaryAddon struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	RealID     string `json:"realId"`
+	ProviderID string `json:"providerId"`
+}
@@ -0,0 +1,68 @@
+// Package self manage the current user: profile, emails, SSH keys and summary.
+package self
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"go.clever-cloud.dev/client"
+)
+
+// Get the current user profile.
+func Get(ctx context.Context, c *client.Client) client.Response[User] {
+	return client.Get[User](ctx, c, "/v2/self")
+}
+
+// Update the current user profile.
+func Update(ctx context.Context, c *client.Client, update UserUpdate) client.Response[User] {
+	return client.Put[User](ctx, c, "/v2/self", update)
+}
+
+// List secondary emails of the current user.
+func ListEmails(ctx context.Context, c *client.Client) client.Response[[]string] {
+	return client.Get[[]string](ctx, c, "/v2/self/emails")
+}
+
+// Add a secondary email, a confirmation is sent to it.
+func AddEmail(ctx context.Context, c *client.Client, email string) client.Response[client.Nothing] {
+	path := fmt.Sprintf("/v2/self/emails/%s", url.PathEscape(email))
+
+	return client.Put[client.Nothing](ctx, c, path, nil)
+}
+
+// Remove a secondary email.
+func RemoveEmail(ctx context.Context, c *client.Client, email string) client.Response[client.Nothing] {
+	path := fmt.Sprintf("/v2/self/emails/%s", url.PathEscape(email))
+
+	return client.Delete[client.Nothing](ctx, c, path)
+}
+
+// List SSH keys of the current user.
+func ListSSHKeys(ctx context.Context, c *client.Client) client.Response[[]SSHKey] {
+	return client.Get[[]SSHKey](ctx, c, "/v2/self/keys")
+}
+
+// Add an SSH public key, in OpenSSH authorized_keys format.
+func AddSSHKey(ctx context.Context, c *client.Client, name, publicKey string) client.Response[client.Nothing] {
+	path := fmt.Sprintf("/v2/self/keys/%s", url.PathEscape(name))
+
+	return client.Put[client.Nothing](ctx, c, path, publicKey)
+}
+
+// Remove an SSH key by name.
+func RemoveSSHKey(ctx context.Context, c *client.Client, name string) client.Response[client.Nothing] {
+	path := fmt.Sprintf("/v2/self/keys/%s", url.PathEscape(name))
+
+	return client.Delete[client.Nothing](ctx, c, path)
+}
+
+// List third-party accounts linked to the current user.
+func ListLinkedAccounts(ctx context.Context, c *client.Client) client.Response[[]LinkedAccount] {
+	return client.Get[[]LinkedAccount](ctx, c, "/v2/self/linked-accounts")
+}
+
+// Summary of the user and organisations they can access, with their applications and add-ons.
+func GetSummary(ctx context.Context, c *client.Client) client.Response[Summary] {
+	return client.Get[Summary](ctx, c, "/v2/summary")
+}
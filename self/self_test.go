@@ -0,0 +1,112 @@
+package self_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.clever-cloud.dev/client/internal/clienttest"
+	"go.clever-cloud.dev/client/self"
+)
+
+func Test_Get(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/self",
+		Response: `{"id":"user_1","email":"me@example.com","name":"Me","preferredMFA":"TOTP"}`,
+	})
+
+	user := clienttest.Payload(t, self.Get(context.Background(), c))
+	if user.ID != "user_1" || !user.MFAEnabled() {
+		t.Errorf("unexpected user: %+v", user)
+	}
+}
+
+func Test_Update(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodPut,
+		Path:     "/v2/self",
+		Response: `{"id":"user_1","name":"Renamed"}`,
+		Check: func(t *testing.T, r *http.Request) {
+			var body map[string]string
+			clienttest.DecodeBody(t, r, &body)
+
+			if len(body) != 1 || body["name"] != "Renamed" {
+				t.Errorf("expect only the name to be sent, got %+v", body)
+			}
+		},
+	})
+
+	user := clienttest.Payload(t, self.Update(context.Background(), c, self.UserUpdate{Name: "Renamed"}))
+	if user.Name != "Renamed" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+}
+
+func Test_Emails(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t,
+		clienttest.Endpoint{Method: http.MethodGet, Path: "/v2/self/emails", Response: `["other@example.com"]`},
+		clienttest.Endpoint{Method: http.MethodPut, Path: "/v2/self/emails/other@example.com"},
+		clienttest.Endpoint{Method: http.MethodDelete, Path: "/v2/self/emails/other@example.com"},
+	)
+	ctx := context.Background()
+
+	if emails := clienttest.Items(t, self.ListEmails(ctx, c), 1); emails[0] != "other@example.com" {
+		t.Errorf("unexpected emails: %v", emails)
+	}
+
+	clienttest.Payload(t, self.AddEmail(ctx, c, "other@example.com"))
+	clienttest.Payload(t, self.RemoveEmail(ctx, c, "other@example.com"))
+}
+
+func Test_SSHKeys(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t,
+		clienttest.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/v2/self/keys",
+			Response: `[{"name":"laptop","key":"ssh-ed25519 AAAA","fingerprint":"SHA256:x"}]`,
+		},
+		clienttest.Endpoint{
+			Method: http.MethodPut,
+			Path:   "/v2/self/keys/laptop",
+			Check: func(t *testing.T, r *http.Request) {
+				var key string
+				if clienttest.DecodeBody(t, r, &key); key != "ssh-ed25519 AAAA" {
+					t.Errorf("unexpected key: %q", key)
+				}
+			},
+		},
+		clienttest.Endpoint{Method: http.MethodDelete, Path: "/v2/self/keys/laptop"},
+	)
+	ctx := context.Background()
+
+	if keys := clienttest.Items(t, self.ListSSHKeys(ctx, c), 1); keys[0].Fingerprint != "SHA256:x" {
+		t.Errorf("unexpected keys: %+v", keys)
+	}
+
+	clienttest.Payload(t, self.AddSSHKey(ctx, c, "laptop", "ssh-ed25519 AAAA"))
+	clienttest.Payload(t, self.RemoveSSHKey(ctx, c, "laptop"))
+}
+
+func Test_GetSummary(t *testing.T) {
+	t.Parallel()
+
+	c := clienttest.NewClient(t, clienttest.Endpoint{
+		Method:   http.MethodGet,
+		Path:     "/v2/summary",
+		Response: `{"user":{"id":"user_1"},"organisations":[{"id":"orga_1","applications":[{"id":"app_1"}]}]}`,
+	})
+
+	summary := clienttest.Payload(t, self.GetSummary(context.Background(), c))
+	if len(summary.Organisations) != 1 || len(summary.Organisations[0].Applications) != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
@@ -0,0 +1,638 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.clever-cloud.dev/client"
+)
+
+func Test_Stream_reconnect(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+
+	connections := 0
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		connections++
+
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("connection %d is not signed", connections)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		switch connections {
+		case 1:
+			_, _ = fmt.Fprint(w, "retry: 10\nid: 1\ndata: a\n\n")
+		case 2:
+			if r.Header.Get("Last-Event-ID") != "1" {
+				t.Errorf("unexpected Last-Event-ID: %q", r.Header.Get("Last-Event-ID"))
+			}
+
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 3:
+			_, _ = fmt.Fprint(w, "id: 2\ndata: b\n\n")
+		default:
+			if r.Header.Get("Last-Event-ID") != "2" {
+				t.Errorf("unexpected Last-Event-ID: %q", r.Header.Get("Last-Event-ID"))
+			}
+
+			// do not reconnect
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL), client.WithBearerAuth("token"))
+	reconnects := []client.ReconnectEvent{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res := client.Stream[client.Nothing](ctx, c, "/events", client.WithReconnect(func(ev client.ReconnectEvent) {
+		reconnects = append(reconnects, ev)
+	}))
+	if res.HasError() {
+		t.Fatalf("unexpected error: %v", res.Error())
+	}
+
+	data := []string{}
+	for ev := range res.Payload() {
+		data = append(data, string(ev.Data))
+	}
+
+	if res.Error() != nil {
+		t.Errorf("unexpected error: %v", res.Error())
+	}
+
+	if len(data) != 2 || data[0] != "a" || data[1] != "b" {
+		t.Errorf("unexpected events: %v", data)
+	}
+
+	if len(reconnects) != 3 {
+		t.Fatalf("expect 3 reconnections, got %+v", reconnects)
+	}
+
+	if reconnects[0].Delay != 10*time.Millisecond || reconnects[0].LastEventID != "1" || reconnects[0].Err != nil {
+		t.Errorf("expect server reconnection time to be used, got %+v", reconnects[0])
+	}
+
+	if reconnects[1].Attempt != 2 || !client.IsServerError(reconnects[1].Err) {
+		t.Errorf("expect a second attempt after the server error, got %+v", reconnects[1])
+	}
+
+	if reconnects[2].Attempt != 1 || reconnects[2].LastEventID != "2" {
+		t.Errorf("expect attempts to restart once connected, got %+v", reconnects[2])
+	}
+}
+
+func Test_Stream_reconnectUntilClose(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "retry: 1\ndata: a\n\n")
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+
+	res := client.Stream[client.Nothing](context.Background(), c, "/events", client.WithReconnect(nil))
+
+	for i := 0; i < 3; i++ {
+		if _, ok := <-res.Payload(); !ok {
+			t.Fatalf("expect stream to be reopened, closed after %d events: %v", i, res.Error())
+		}
+	}
+
+	res.Close()
+
+	// drain until the loop stops
+	for range res.Payload() {
+	}
+}
+
+type logLine struct {
+	Message string `json:"message"`
+}
+
+type heartbeat struct {
+	At int64 `json:"at"`
+}
+
+func Test_Stream_decoders(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, "data: {\"message\":\"hello\"}\n\nevent: heartbeat\ndata: {\"at\":42}\n\nevent: end\ndata: bye\n\n")
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+
+	res := client.Stream[logLine](context.Background(), c, "/logs",
+		client.WithEventDecoder("heartbeat", client.DecodeJSON[heartbeat]),
+		client.WithEventDecoder("end", func(data []byte) (interface{}, error) { return string(data), nil }),
+	)
+
+	decoded := []interface{}{}
+
+	for ev := range res.Payload() {
+		value, err := ev.Decoded()
+		if err != nil {
+			t.Fatalf("cannot decode %s: %v", ev, err)
+		}
+
+		decoded = append(decoded, value)
+	}
+
+	if len(decoded) != 3 {
+		t.Fatalf("expect 3 events, got %+v (%v)", decoded, res.Error())
+	}
+
+	if line, ok := decoded[0].(*logLine); !ok || line.Message != "hello" {
+		t.Errorf("expect a log line, got %#v", decoded[0])
+	}
+
+	if beat, ok := decoded[1].(*heartbeat); !ok || beat.At != 42 {
+		t.Errorf("expect a heartbeat, got %#v", decoded[1])
+	}
+
+	if decoded[2] != "bye" {
+		t.Errorf("expect end data, got %#v", decoded[2])
+	}
+}
+
+func Test_StreamEvent_Value(t *testing.T) {
+	t.Parallel()
+
+	ev := &client.StreamEvent[logLine]{Event: "message", Data: []byte(`{"message":"hello"}`)}
+
+	line, err := ev.Value()
+	if err != nil || line.Message != "hello" {
+		t.Errorf("unexpected value: %+v (%v)", line, err)
+	}
+
+	ev.Data = []byte("not json")
+	if _, err := ev.Value(); err == nil {
+		t.Error("expect an error on invalid data")
+	}
+
+	if _, err := ev.Decoded(); err == nil {
+		t.Error("expect an error on invalid data")
+	}
+}
+
+// streamServer serves the given handler, the returned client has its own connections,
+// so that the server and the client can be shut down entirely to count goroutines.
+func streamServer(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+
+	api := httptest.NewServer(handler)
+	transport := &http.Transport{}
+
+	t.Cleanup(func() {
+		transport.CloseIdleConnections()
+		api.Close()
+	})
+
+	return client.New(
+		client.WithEndpoint(api.URL),
+		client.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+}
+
+// checkGoroutines fails the test if goroutines started during it are still running at the end.
+// Tests using it must not be parallel, and must register it before other cleanups.
+func checkGoroutines(t *testing.T) {
+	t.Helper()
+
+	before := runtime.NumGoroutine()
+
+	t.Cleanup(func() {
+		deadline := time.Now().Add(2 * time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if after := runtime.NumGoroutine(); after > before {
+			stacks := make([]byte, 1<<16)
+			stacks = stacks[:runtime.Stack(stacks, true)]
+
+			t.Errorf("%d goroutines leaked:\n%s", after-before, stacks)
+		}
+	})
+}
+
+// endless sends events until the client goes away.
+func endless(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+
+	for i := 0; ; i++ {
+		if _, err := fmt.Fprintf(w, "id: %d\ndata: %d\n\n", i, i); err != nil {
+			return
+		}
+
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func Test_Stream_close(t *testing.T) {
+	checkGoroutines(t)
+
+	c := streamServer(t, endless)
+	res := client.Stream[client.Nothing](context.Background(), c, "/events")
+
+	// Error() is safe to call while the stream runs
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_ = res.Error()
+			}
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		if _, ok := <-res.Payload(); !ok {
+			t.Fatalf("stream closed early: %v", res.Error())
+		}
+	}
+
+	res.Close()
+	res.Close()
+
+	for range res.Payload() {
+	}
+
+	if res.Error() != nil {
+		t.Errorf("expect a clean end on Close, got %v", res.Error())
+	}
+}
+
+func Test_Stream_closeWithoutReading(t *testing.T) {
+	checkGoroutines(t)
+
+	c := streamServer(t, endless)
+	res := client.Stream[client.Nothing](context.Background(), c, "/events", client.WithReconnect(nil))
+
+	// let the payload buffer fill up
+	time.Sleep(50 * time.Millisecond)
+	res.Close()
+
+	for range res.Payload() {
+	}
+}
+
+func Test_Stream_closeWhileReconnecting(t *testing.T) {
+	checkGoroutines(t)
+
+	c := streamServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, "retry: 60000\ndata: a\n\n")
+	})
+
+	reconnecting := make(chan struct{})
+	res := client.Stream[client.Nothing](context.Background(), c, "/events", client.WithReconnect(func(client.ReconnectEvent) {
+		close(reconnecting)
+	}))
+
+	<-res.Payload()
+	<-reconnecting
+
+	start := time.Now()
+
+	res.Close()
+
+	for range res.Payload() {
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close did not interrupt the reconnection delay, stopped after %s", elapsed)
+	}
+}
+
+func Test_Stream_contextCancel(t *testing.T) {
+	checkGoroutines(t)
+
+	c := streamServer(t, endless)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	res := client.Stream[client.Nothing](ctx, c, "/events")
+
+	<-res.Payload()
+	cancel()
+
+	for range res.Payload() {
+	}
+
+	if !errors.Is(res.Error(), context.Canceled) {
+		t.Errorf("expect the context error, got %v", res.Error())
+	}
+}
+
+func Test_Stream_errors(t *testing.T) {
+	checkGoroutines(t)
+
+	failing := client.New(client.WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("network is down")
+	})}))
+
+	res := client.Stream[client.Nothing](context.Background(), failing, "/events")
+	if !res.HasError() {
+		t.Error("expect the network error")
+	}
+
+	for range res.Payload() {
+	}
+
+	res.Close()
+
+	c := streamServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	res = client.Stream[client.Nothing](context.Background(), c, "/events")
+	if !client.IsForbidden(res.Error()) {
+		t.Errorf("expect an API error, got %v", res.Error())
+	}
+
+	for range res.Payload() {
+	}
+
+	res.Close()
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_StreamResponse_Each(t *testing.T) {
+	checkGoroutines(t)
+
+	c := streamServer(t, endless)
+	errEnough := errors.New("enough")
+
+	count := 0
+	res := client.Stream[client.Nothing](context.Background(), c, "/events")
+
+	err := res.Each(context.Background(), func(ev *client.StreamEvent[client.Nothing]) error {
+		count++
+		if count == 3 {
+			return errEnough
+		}
+
+		return nil
+	})
+	if !errors.Is(err, errEnough) || count != 3 {
+		t.Errorf("expect Each to stop on callback error, got %v after %d events", err, count)
+	}
+
+	// the stream is closed
+	for range res.Payload() {
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	res = client.Stream[client.Nothing](context.Background(), c, "/events")
+	if err := res.Each(ctx, func(*client.StreamEvent[client.Nothing]) error { return nil }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expect Each to stop with its context, got %v", err)
+	}
+
+	for range res.Payload() {
+	}
+}
+
+func Test_StreamResponse_EachEnd(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		_, _ = fmt.Fprint(w, "data: a\n\ndata: b\n\n")
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+	data := []string{}
+
+	err := client.Stream[client.Nothing](context.Background(), c, "/events").Each(context.Background(), func(ev *client.StreamEvent[client.Nothing]) error {
+		data = append(data, string(ev.Data))
+
+		return nil
+	})
+	if err != nil || len(data) != 2 {
+		t.Errorf("expect all events and no error, got %v (%v)", data, err)
+	}
+
+	err = client.Stream[client.Nothing](context.Background(), c, "/forbidden").Each(context.Background(), func(*client.StreamEvent[client.Nothing]) error {
+		t.Error("expect no event")
+
+		return nil
+	})
+	if !client.IsForbidden(err) {
+		t.Errorf("expect the stream error, got %v", err)
+	}
+}
+
+func Test_Stream_noContent(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+
+	connections := 0
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		connections++
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+
+	res := client.Stream[client.Nothing](context.Background(), c, "/events", client.WithReconnect(func(ev client.ReconnectEvent) {
+		t.Errorf("expect no reconnection, got %+v", ev)
+	}))
+
+	for range res.Payload() {
+		t.Error("expect no event")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if res.Error() != nil || connections != 1 {
+		t.Errorf("expect a clean end after a single connection, got %d connections (%v)", connections, res.Error())
+	}
+}
+
+func Test_Stream_reconnectGivesUp(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+
+	connections := 0
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		connections++
+		if connections > 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = fmt.Fprint(w, "data: a\n\n")
+	}))
+	defer api.Close()
+
+	c := client.New(
+		client.WithEndpoint(api.URL),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}),
+	)
+
+	res := client.Stream[client.Nothing](context.Background(), c, "/events", client.WithReconnect(nil))
+
+	for range res.Payload() {
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !client.IsServerError(res.Error()) || connections != 3 {
+		t.Errorf("expect to give up after 2 reconnections, got %d connections (%v)", connections, res.Error())
+	}
+}
+
+// expiringAuthenticator signs the first request only.
+type expiringAuthenticator struct {
+	mu    sync.Mutex
+	signs int
+}
+
+func (a *expiringAuthenticator) Sign(req *http.Request) {}
+
+func (a *expiringAuthenticator) SignRequest(ctx context.Context, req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.signs++
+	if a.signs > 1 {
+		return errors.New("credentials expired")
+	}
+
+	return nil
+}
+
+func Test_Stream_reconnectSignError(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, "data: a\n\n")
+	}))
+	defer api.Close()
+
+	auth := &expiringAuthenticator{}
+	c := client.New(client.WithEndpoint(api.URL), client.WithCredentialProvider(client.StaticProvider(auth)))
+
+	reconnects := 0
+	res := client.Stream[client.Nothing](context.Background(), c, "/events", client.WithReconnect(func(client.ReconnectEvent) {
+		reconnects++
+	}))
+
+	for range res.Payload() {
+	}
+
+	if res.Error() == nil || reconnects != 1 {
+		t.Errorf("expect to stop on the sign error, got %d reconnections (%v)", reconnects, res.Error())
+	}
+}
+
+func Test_Stream_reconnectResponse(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+
+	connections := 0
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		connections++
+
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		w.Header().Set("Sozu-Id", fmt.Sprintf("sozu_%d", connections))
+
+		switch connections {
+		case 1:
+			_, _ = fmt.Fprint(w, "retry: 1\ndata: a\n\n")
+		case 2:
+			// any 2xx event stream is accepted
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprint(w, "data: b\n\n")
+		case 3:
+			// not an event stream
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, "{}")
+		default:
+			t.Errorf("expect no reconnection after a wrong content type")
+		}
+	}))
+	defer api.Close()
+
+	c := client.New(client.WithEndpoint(api.URL))
+
+	res := client.Stream[client.Nothing](context.Background(), c, "/events", client.WithReconnect(nil))
+
+	data := []string{}
+	for ev := range res.Payload() {
+		data = append(data, string(ev.Data))
+
+		if len(data) == 2 && (res.StatusCode() != http.StatusAccepted || res.SozuID() != "sozu_2") {
+			t.Errorf("expect the reconnected response, got status %d from %s", res.StatusCode(), res.SozuID())
+		}
+	}
+
+	if len(data) != 2 || data[1] != "b" {
+		t.Errorf("unexpected events: %v", data)
+	}
+
+	if res.Error() == nil {
+		t.Errorf("expect an error for a reconnection which is not an event stream")
+	}
+}